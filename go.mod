@@ -1,6 +1,6 @@
 module github.com/YuitoSato/goexhauerrors
 
-go 1.23.0
+go 1.24.0
 
 require (
 	github.com/golangci/plugin-module-register v0.1.2
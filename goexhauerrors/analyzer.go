@@ -1,10 +1,15 @@
 package goexhauerrors
 
 import (
+	"reflect"
+
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/analyzer"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/callsite"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/checker"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/detector"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/errset"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/implindex"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
@@ -14,14 +19,54 @@ import (
 // ignorePackages is a comma-separated list of package paths to ignore.
 var ignorePackages string
 
+// checkFunctions is a comma-separated list of error-checking helper functions
+// and the errors they cover.
+var checkFunctions string
+
+// wrapperFunctions and checkerFunctions extend the built-in tables of error
+// wrapping and errors.Is/As-equivalent functions.
+var (
+	wrapperFunctions string
+	checkerFunctions string
+)
+
+// writeImplIndex and implIndex configure the implementation index, which resolves
+// interface methods whose implementations are not imported by the caller.
+var (
+	writeImplIndex string
+	implIndex      string
+)
+
+// mockFiles and mockPackages select implementations excluded from interface error sets,
+// in addition to types annotated with //goexhauerrors:mock.
+var (
+	mockFiles    string
+	mockPackages string
+)
+
 func init() {
 	Analyzer.Flags.StringVar(&ignorePackages, "ignorePackages", "",
 		"comma-separated list of package paths to ignore (e.g., gorm.io/gorm,database/sql)")
+	Analyzer.Flags.StringVar(&checkFunctions, "checkFunctions", "",
+		"comma-separated list of error-checking helpers and the errors they cover (e.g., example.com/apperr.IsNotFound=example.com/apperr.ErrNotFound|example.com/apperr.ErrGone); code helpers map a constant to errors (e.g., example.com/apperr.Code=example.com/apperr.CodeNotFound=example.com/apperr.ErrNotFound)")
+	Analyzer.Flags.StringVar(&wrapperFunctions, "wrapperFunctions", "",
+		"comma-separated list of additional error wrapping functions and the argument they wrap: N, format:N or cause:N (e.g., example.com/errs.Annotate=1,example.com/errs.Newf=format:0)")
+	Analyzer.Flags.StringVar(&checkerFunctions, "checkerFunctions", "",
+		"comma-separated list of additional errors.Is/As equivalents (e.g., example.com/errs.Matches=Is,example.com/errs.Extract=As)")
+	Analyzer.Flags.StringVar(&writeImplIndex, "writeImplIndex", "",
+		"directory to write the errors of interface implementations to, for use with -implIndex in a later run")
+	Analyzer.Flags.StringVar(&implIndex, "implIndex", "",
+		"directory written by -writeImplIndex, used to resolve interface methods implemented in packages the caller does not import")
+	Analyzer.Flags.StringVar(&mockFiles, "mockFiles", "",
+		"comma-separated list of file name patterns whose types are mocks excluded from interface error sets (e.g., *_test.go,mock_*.go)")
+	Analyzer.Flags.StringVar(&mockPackages, "mockPackages", "",
+		"comma-separated list of package paths whose types are mocks excluded from interface error sets (e.g., example.com/app/mocks)")
 }
 
 var Analyzer = &analysis.Analyzer{
 	Name: "exhaustiveerrors",
 	Doc:  "checks that all error types returned by functions are exhaustively checked with errors.Is/As",
+	URL:  "https://github.com/YuitoSato/goexhauerrors",
 	Run:  run,
 	Requires: []*analysis.Analyzer{
 		inspect.Analyzer,
@@ -34,18 +79,62 @@ var Analyzer = &analysis.Analyzer{
 		(*facts.InterfaceMethodFact)(nil),
 		(*facts.FunctionParamCallFlowFact)(nil),
 		(*facts.ParameterCheckedErrorsFact)(nil),
+		(*facts.CheckFunctionFact)(nil),
+		(*facts.UnwrapFact)(nil),
+		(*facts.ImplementsFact)(nil),
+		(*facts.MockFact)(nil),
+		(*facts.ErrorContractFact)(nil),
+		(*facts.ErrorParentFact)(nil),
+		(*facts.IteratorErrorsFact)(nil),
+		(*facts.NilableResultsFact)(nil),
+		(*facts.FuncResultErrorsFact)(nil),
 	},
+	// The result is the package's exported error sets, consumed by DiffAnalyzer.
+	ResultType: reflect.TypeOf((*errset.Snapshot)(nil)),
+}
+
+// WholeProgramAnalyzer returns a copy of Analyzer that also resolves the dynamic calls
+// of callSites, the call-site index built by the whole-program mode.
+func WholeProgramAnalyzer(callSites callsite.Index) *analysis.Analyzer {
+	a := *Analyzer
+	a.Run = func(pass *analysis.Pass) (interface{}, error) {
+		return runWithCallSites(pass, callSites)
+	}
+	return &a
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
+	return runWithCallSites(pass, nil)
+}
+
+func runWithCallSites(pass *analysis.Pass, callSites callsite.Index) (interface{}, error) {
 	// Set ignore packages for the internal package
 	internal.SetIgnorePackages(ignorePackages)
+	internal.SetCheckFunctions(checkFunctions)
+	internal.SetWrapperFunctions(wrapperFunctions)
+	internal.SetCheckerFunctions(checkerFunctions)
+	internal.SetMockFiles(mockFiles)
+	internal.SetMockPackages(mockPackages)
+	implindex.SetWriteDir(writeImplIndex)
+	implindex.SetReadDir(implIndex)
+	if err := implindex.Load(); err != nil {
+		return nil, err
+	}
 
 	// Phase 1: Detect local errors (sentinels and custom types) in this package and export facts
 	localErrors := detector.DetectLocalErrors(pass)
 
+	// Phase 1b: Detect error-checking helpers annotated with //goexhauerrors:checks
+	analyzer.AnalyzeCheckFunctions(pass, localErrors)
+
+	// Phase 1c: Detect mocks annotated with //goexhauerrors:mock
+	analyzer.AnalyzeMockTypes(pass)
+
+	// Phase 1d: Detect interface method contracts annotated with //goexhauerrors:returns
+	analyzer.AnalyzeErrorContracts(pass, localErrors)
+
 	// Phase 2: Analyze function bodies for returns
-	localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls := analyzer.AnalyzeFunctionReturns(pass, localErrors)
+	localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls := analyzer.AnalyzeFunctionReturns(pass, localErrors, callSites)
 
 	// Phase 2b: Analyze closures assigned to variables
 	analyzer.AnalyzeClosures(pass, localErrors)
@@ -56,18 +145,18 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	// Phase 2d: Compute interface method facts (after ParameterCheckedErrorsFact is available)
 	analyzer.ComputeInterfaceMethodFacts(pass, localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls)
 
-	// Phase 2e: Compute interface method facts for imported interfaces (DI pattern support)
-	analyzer.ComputeImportedInterfaceMethodFacts(pass, localFacts, localCallFlowFacts, interfaceImpls)
+	// Phase 2e: Export implementations of imported interfaces (DI pattern support)
+	if err := analyzer.ComputeImportedInterfaceMethodFacts(pass, localFacts, localCallFlowFacts, interfaceImpls); err != nil {
+		return nil, err
+	}
 
-	// Phase 3: Check call sites for exhaustive errors.Is checks
-	checker.CheckCallSites(pass, interfaceImpls)
+	// Phase 2f: Verify implementations against interface method contracts
+	analyzer.VerifyErrorContracts(pass, localErrors, localFacts, interfaceImpls)
 
-	// Phase 4: Process deferred function checks from earlier packages.
-	// When a checker couldn't find interface method errors in the global store
-	// (because the implementation package hadn't been analyzed yet), it registers
-	// a deferred re-analysis callback. By now, this package's facts are in the
-	// global store, so deferred functions may find what they need.
-	facts.ProcessDeferredFunctionChecks()
+	// Phase 3: Check call sites for exhaustive errors.Is checks.
+	// Every diagnostic is reported through this pass before run returns; no check is
+	// deferred until other packages have been analyzed.
+	checker.CheckCallSites(pass, interfaceImpls, callSites)
 
-	return nil, nil
+	return errset.Collect(pass), nil
 }
@@ -2,10 +2,15 @@ package analyzer
 
 import (
 	"go/ast"
+	"go/token"
 	"go/types"
+	"sort"
+	"strings"
 
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/callsite"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/detector"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/implindex"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
 	ssaanalysis "github.com/YuitoSato/goexhauerrors/goexhauerrors/ssa"
 	"golang.org/x/tools/go/analysis"
@@ -25,7 +30,7 @@ type funcInfo struct {
 // It uses iterative analysis to handle factory functions that call other
 // functions returning errors, combined with SSA-based analysis to track
 // errors through variables.
-func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors) (map[*types.Func]*facts.FunctionErrorsFact, map[*types.Func]*facts.ParameterFlowFact, map[*types.Func]*facts.FunctionParamCallFlowFact, *internal.InterfaceImplementations) {
+func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors, callSites callsite.Index) (map[*types.Func]*facts.FunctionErrorsFact, map[*types.Func]*facts.ParameterFlowFact, map[*types.Func]*facts.FunctionParamCallFlowFact, *internal.InterfaceImplementations) {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
 	// Discover interface implementations in this package
@@ -33,6 +38,7 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 
 	// Collect all function declarations
 	var funcs []funcInfo
+	var iterators []*types.Func
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 	}
@@ -53,6 +59,9 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 		}
 
 		sig := fn.Type().(*types.Signature)
+		if isIteratorFunc(sig) {
+			iterators = append(iterators, fn)
+		}
 		errorPositions := internal.FindErrorReturnPositions(sig)
 		if len(errorPositions) == 0 {
 			return
@@ -69,6 +78,7 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 	localFacts := make(map[*types.Func]*facts.FunctionErrorsFact)
 	localParamFlowFacts := make(map[*types.Func]*facts.ParameterFlowFact)
 	localCallFlowFacts := make(map[*types.Func]*facts.FunctionParamCallFlowFact)
+	localIteratorFacts := make(map[*types.Func]*facts.IteratorErrorsFact)
 
 	// Iterate until no new facts are discovered (AST-based + SSA-based analysis)
 	for {
@@ -76,6 +86,16 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 
 		// Create SSA analyzer with current local facts
 		ssaAnalyzer := ssaanalysis.NewAnalyzer(pass, localErrs, localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls)
+		ssaAnalyzer.LocalIteratorFacts = localIteratorFacts
+		ssaAnalyzer.LocalFieldFacts = collectFuncValueFacts(pass, localErrs, localFacts)
+		ssaAnalyzer.LocalFuncResultFacts = collectFuncResultFacts(pass, localErrs, localFacts)
+		ssaAnalyzer.CallSites = callSites
+
+		// Errors yielded by iterators reach the functions ranging over them, and iterators
+		// yield the errors of the functions they call
+		for _, fn := range iterators {
+			changed = mergeIteratorErrorsFact(localIteratorFacts, fn, ssaAnalyzer.TraceIteratorYields(fn)) || changed
+		}
 
 		for _, fi := range funcs {
 			// Phase A: Detect parameter flow (for error-typed parameters)
@@ -90,7 +110,10 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 
 			// Phase B: Analyze errors (AST-based + SSA-based)
 			fact := &facts.FunctionErrorsFact{}
-			analyzeReturns(pass, fi.body, fi.errorPositions, localErrs, fact, localFacts)
+			// Errors replaced by a deferred rewrite of the named results never reach the caller
+			if !ssaAnalyzer.ReplacesReturnedErrors(fi.fn, fi.errorPositions) {
+				analyzeReturns(pass, fi.body, fi.errorPositions, localErrs, fact, localFacts)
+			}
 			for _, s := range ssaAnalyzer.TraceReturnStatements(fi.fn, fi.errorPositions) {
 				fact.AddError(s)
 			}
@@ -103,10 +126,24 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 	}
 
 	// Build set of valid errors (local + imported with facts)
-	validErrors := buildValidErrors(pass, localErrs)
+	validErrors := buildValidErrors(pass, localErrs, callSites)
 
-	// Export all discovered facts, filtering out invalid errors
+	// Export all discovered facts, filtering out invalid errors.
+	// NilableResultsFact lets callers tell a function failing with a custom error pointer
+	// from a constructor of the error.
+	ssaAnalyzer := ssaanalysis.NewAnalyzer(pass, localErrs, localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls)
 	for fn, fact := range localFacts {
+		fact.FilterByValidErrors(validErrors)
+		if len(fact.Errors) > 0 {
+			pass.ExportObjectFact(fn, fact)
+			if results := ssaAnalyzer.NilableErrorPointerResults(fn); len(results) > 0 {
+				pass.ExportObjectFact(fn, &facts.NilableResultsFact{Results: results})
+			}
+		}
+	}
+
+	// Export IteratorErrorsFact for range loops over the iterators
+	for fn, fact := range localIteratorFacts {
 		fact.FilterByValidErrors(validErrors)
 		if len(fact.Errors) > 0 {
 			pass.ExportObjectFact(fn, fact)
@@ -131,6 +168,21 @@ func AnalyzeFunctionReturns(pass *analysis.Pass, localErrs *detector.LocalErrors
 	return localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls
 }
 
+// mergeIteratorErrorsFact merges yielded errors into the local iterator facts map.
+// Returns true if the map was changed.
+func mergeIteratorErrorsFact(m map[*types.Func]*facts.IteratorErrorsFact, fn *types.Func, errs []facts.ErrorInfo) bool {
+	existing, ok := m[fn]
+	if !ok {
+		existing = &facts.IteratorErrorsFact{}
+		m[fn] = existing
+	}
+	oldLen := len(existing.Errors)
+	for _, errInfo := range errs {
+		existing.AddError(errInfo)
+	}
+	return !ok || len(existing.Errors) > oldLen
+}
+
 // mergeFunctionErrorsFact merges a new fact into the local facts map.
 // Returns true if the map was changed.
 func mergeFunctionErrorsFact(m map[*types.Func]*facts.FunctionErrorsFact, fn *types.Func, newFact *facts.FunctionErrorsFact) bool {
@@ -177,7 +229,7 @@ func mergeCallFlowFact(m map[*types.Func]*facts.FunctionParamCallFlowFact, fn *t
 // A error is valid if:
 // 1. It's a local error (var or type) in the current package
 // 2. It has an imported ErrorFact (excluding ignored packages)
-func buildValidErrors(pass *analysis.Pass, localErrs *detector.LocalErrors) map[string]bool {
+func buildValidErrors(pass *analysis.Pass, localErrs *detector.LocalErrors, callSites callsite.Index) map[string]bool {
 	valid := make(map[string]bool)
 
 	// Add local error variables
@@ -195,7 +247,7 @@ func buildValidErrors(pass *analysis.Pass, localErrs *detector.LocalErrors) map[
 	// Note: Imported errors are validated by checking ImportObjectFact during analysis
 	// We need to also allow imported errors that have facts
 	// This is done by scanning all referenced packages for exported facts
-	for _, imp := range pass.Pkg.Imports() {
+	for _, imp := range internal.SortedImports(pass.Pkg) {
 		// Skip ignored packages
 		if internal.ShouldIgnorePackage(imp.Path()) {
 			continue
@@ -221,6 +273,11 @@ func buildValidErrors(pass *analysis.Pass, localErrs *detector.LocalErrors) map[
 		}
 	}
 
+	// In whole-program mode, errors of callees in packages that are not imported are valid too
+	for _, key := range callSites.KnownErrors() {
+		valid[key] = true
+	}
+
 	return valid
 }
 
@@ -235,7 +292,7 @@ func analyzeReturns(pass *analysis.Pass, body *ast.BlockStmt, errorPositions []i
 
 		for _, pos := range errorPositions {
 			if pos < len(ret.Results) {
-				analyzeErrorExpr(pass, ret.Results[pos], localErrs, fact, false, localFacts)
+				analyzeErrorExpr(pass, ret.Results[pos], localErrs, fact, localFacts)
 			}
 		}
 
@@ -245,7 +302,7 @@ func analyzeReturns(pass *analysis.Pass, body *ast.BlockStmt, errorPositions []i
 
 // analyzeErrorExpr analyzes an expression to find errors.
 // If localFacts is non-nil, it also checks local function facts for same-package functions.
-func analyzeErrorExpr(pass *analysis.Pass, expr ast.Expr, localErrs *detector.LocalErrors, fact *facts.FunctionErrorsFact, wrapped bool, localFacts map[*types.Func]*facts.FunctionErrorsFact) {
+func analyzeErrorExpr(pass *analysis.Pass, expr ast.Expr, localErrs *detector.LocalErrors, fact *facts.FunctionErrorsFact, localFacts map[*types.Func]*facts.FunctionErrorsFact) {
 	switch e := expr.(type) {
 	case *ast.Ident:
 		obj := pass.TypesInfo.Uses[e]
@@ -258,7 +315,6 @@ func analyzeErrorExpr(pass *analysis.Pass, expr ast.Expr, localErrs *detector.Lo
 				fact.AddError(facts.ErrorInfo{
 					PkgPath: pass.Pkg.Path(),
 					Name:    varObj.Name(),
-					Wrapped: wrapped,
 				})
 				return
 			}
@@ -267,7 +323,6 @@ func analyzeErrorExpr(pass *analysis.Pass, expr ast.Expr, localErrs *detector.Lo
 				fact.AddError(facts.ErrorInfo{
 					PkgPath: errorFact.PkgPath,
 					Name:    errorFact.Name,
-					Wrapped: wrapped,
 				})
 			}
 		}
@@ -284,19 +339,25 @@ func analyzeErrorExpr(pass *analysis.Pass, expr ast.Expr, localErrs *detector.Lo
 				fact.AddError(facts.ErrorInfo{
 					PkgPath: errorFact.PkgPath,
 					Name:    errorFact.Name,
-					Wrapped: wrapped,
 				})
 			}
 		}
 
 	case *ast.CallExpr:
-		if internal.IsFmtErrorfCall(pass, e) {
-			analyzeFmtErrorfCall(pass, e, localErrs, fact, localFacts)
+		if wrappedArgs, ok := internal.WrappedArgs(pass, e); ok {
+			w, _ := internal.LookupWrapper(internal.GetCalledFunction(pass, e))
+			carried := &facts.FunctionErrorsFact{}
+			for _, arg := range wrappedArgs {
+				analyzeErrorExpr(pass, arg, localErrs, carried, localFacts)
+			}
+			for _, errInfo := range carried.Errors {
+				fact.AddError(w.Carry(errInfo))
+			}
 			return
 		}
 
 		if compLit := internal.ExtractCompositeLit(e); compLit != nil {
-			analyzeCompositeLit(pass, compLit, localErrs, fact, wrapped)
+			analyzeCompositeLit(pass, compLit, localErrs, fact, 0)
 			return
 		}
 
@@ -317,43 +378,18 @@ func analyzeErrorExpr(pass *analysis.Pass, expr ast.Expr, localErrs *detector.Lo
 	case *ast.UnaryExpr:
 		if e.Op.String() == "&" {
 			if compLit, ok := e.X.(*ast.CompositeLit); ok {
-				analyzeCompositeLit(pass, compLit, localErrs, fact, wrapped)
+				analyzeCompositeLit(pass, compLit, localErrs, fact, facts.FormPointer)
 			}
 		}
 
 	case *ast.CompositeLit:
-		analyzeCompositeLit(pass, e, localErrs, fact, wrapped)
-	}
-}
-
-// analyzeFmtErrorfCall analyzes fmt.Errorf calls for %w wrapped errors.
-// If localFacts is non-nil, it also checks local function facts.
-func analyzeFmtErrorfCall(pass *analysis.Pass, call *ast.CallExpr, localErrs *detector.LocalErrors, fact *facts.FunctionErrorsFact, localFacts map[*types.Func]*facts.FunctionErrorsFact) {
-	if len(call.Args) < 1 {
-		return
-	}
-
-	formatStr := internal.ExtractStringLiteral(call.Args[0])
-	if formatStr == "" {
-		return
-	}
-
-	wrapIndices := internal.FindWrapVerbIndices(formatStr)
-	if len(wrapIndices) == 0 {
-		return
-	}
-
-	for _, wrapIdx := range wrapIndices {
-		argIdx := 1 + wrapIdx
-		if argIdx >= len(call.Args) {
-			continue
-		}
-		analyzeErrorExpr(pass, call.Args[argIdx], localErrs, fact, true, localFacts)
+		analyzeCompositeLit(pass, e, localErrs, fact, facts.FormValue)
 	}
 }
 
 // analyzeCompositeLit checks if a composite literal is a custom error type.
-func analyzeCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLit, localErrs *detector.LocalErrors, fact *facts.FunctionErrorsFact, wrapped bool) {
+// form is the form the literal is returned in, or 0 if not known.
+func analyzeCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLit, localErrs *detector.LocalErrors, fact *facts.FunctionErrorsFact, form facts.ErrorForm) {
 	// Get the type of the composite literal
 	tv := pass.TypesInfo.Types[compLit]
 	if !tv.IsValue() {
@@ -372,7 +408,7 @@ func analyzeCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLit, localEr
 		fact.AddError(facts.ErrorInfo{
 			PkgPath: pass.Pkg.Path(),
 			Name:    typeName.Name(),
-			Wrapped: wrapped,
+			Forms:   form,
 		})
 		return
 	}
@@ -383,96 +419,337 @@ func analyzeCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLit, localEr
 		fact.AddError(facts.ErrorInfo{
 			PkgPath: errorFact.PkgPath,
 			Name:    errorFact.Name,
-			Wrapped: wrapped,
+			Forms:   form,
 		})
 	}
 }
 
-// AnalyzeClosures finds closures assigned to variables and exports facts for them.
-// This handles patterns like: handler := func() error { return ErrX }
+// isIteratorFunc reports whether a function is a range-over-func iterator yielding errors
+// (func (r *Repo) Rows(yield func(Row, error) bool)) or returns one
+// (func (r *Repo) All() iter.Seq2[User, error]).
+func isIteratorFunc(sig *types.Signature) bool {
+	if internal.IteratorYieldErrorIndex(sig) >= 0 {
+		return true
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		if internal.IteratorYieldErrorIndex(sig.Results().At(i).Type()) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AnalyzeClosures finds function values stored in variables and struct fields and exports
+// facts for them. This handles patterns like:
+//
+//	handler := func() error { return ErrX }   // closure assigned to a variable
+//	fn := svc.Load                           // method value
+//	s.onSave = func() error { ... }          // field store
+//	Hooks{OnLoad: svc.Load}                  // struct literal
+//	router.Handle("/x", func() error { ... }) // argument stored into a field by the callee
+//	fns := []func() error{load, save}         // slice literal of functions
+//	opt := WithPort(80)                       // function value returned by a call
+//
+// A variable or field that is assigned several function values gets the union of their errors.
+// Functions returning function values get a FuncResultErrorsFact.
+// Only fields declared in the package under analysis can carry facts.
 func AnalyzeClosures(pass *analysis.Pass, localErrs *detector.LocalErrors) {
+	// Functions returning function values first, so values built by calling them are resolved
+	for fn, fact := range collectFuncResultFacts(pass, localErrs, nil) {
+		pass.ExportObjectFact(fn, fact)
+	}
+	for varObj, fact := range collectFuncValueFacts(pass, localErrs, nil) {
+		pass.ExportObjectFact(varObj, fact)
+	}
+}
+
+// collectFuncResultFacts returns the errors of calling the function values returned by
+// each function of the package, such as the option returned by
+// func WithPort(port int) Option { return func(cfg *Config) error { ... } }.
+// Functions of the package are looked up in localFacts before their exported facts.
+func collectFuncResultFacts(pass *analysis.Pass, localErrs *detector.LocalErrors, localFacts map[*types.Func]*facts.FunctionErrorsFact) map[*types.Func]*facts.FuncResultErrorsFact {
+	result := make(map[*types.Func]*facts.FuncResultErrorsFact)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			positions := funcResultPositions(fn.Type().(*types.Signature))
+			if len(positions) == 0 {
+				continue
+			}
+
+			fact := &facts.FuncResultErrorsFact{}
+			ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.FuncLit:
+					// Returns of nested function literals are not returns of fn
+					return false
+				case *ast.ReturnStmt:
+					for _, pos := range positions {
+						if pos >= len(node.Results) {
+							continue
+						}
+						if errs := funcValueErrors(pass, node.Results[pos], localErrs, localFacts); errs != nil {
+							for _, err := range errs.Errors {
+								fact.AddError(err)
+							}
+						}
+					}
+					return false
+				}
+				return true
+			})
+			if len(fact.Errors) > 0 {
+				result[fn] = fact
+			}
+		}
+	}
+	return result
+}
+
+// funcResultPositions returns the indices of the results of sig that are functions
+// returning errors.
+func funcResultPositions(sig *types.Signature) []int {
+	var positions []int
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		if fnSig, ok := results.At(i).Type().Underlying().(*types.Signature); ok && len(internal.FindErrorReturnPositions(fnSig)) > 0 {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// collectFuncValueFacts returns the errors of the function values stored in each variable
+// and struct field of the package, see AnalyzeClosures. Functions of the package are
+// looked up in localFacts before their exported facts.
+func collectFuncValueFacts(pass *analysis.Pass, localErrs *detector.LocalErrors, localFacts map[*types.Func]*facts.FunctionErrorsFact) map[*types.Var]*facts.FunctionErrorsFact {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	result := make(map[*types.Var]*facts.FunctionErrorsFact)
+
+	paramStores := findParamFieldStores(pass)
 
 	nodeFilter := []ast.Node{
 		(*ast.AssignStmt)(nil),
 		(*ast.ValueSpec)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.CallExpr)(nil),
 	}
 
 	insp.Preorder(nodeFilter, func(n ast.Node) {
-		switch stmt := n.(type) {
+		switch node := n.(type) {
 		case *ast.AssignStmt:
-			analyzeClosureAssignment(pass, stmt, localErrs)
+			for i, rhs := range node.Rhs {
+				if i < len(node.Lhs) && len(node.Lhs) == len(node.Rhs) {
+					analyzeFuncValueStore(pass, node.Lhs[i], rhs, localErrs, localFacts, result)
+				}
+			}
 		case *ast.ValueSpec:
-			analyzeClosureValueSpec(pass, stmt, localErrs)
+			for i, value := range node.Values {
+				if i < len(node.Names) {
+					analyzeFuncValueStore(pass, node.Names[i], value, localErrs, localFacts, result)
+				}
+			}
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					// Only struct literal keys are fields; map literal keys are values
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						if field, ok := pass.TypesInfo.Uses[key].(*types.Var); ok && field.IsField() {
+							analyzeFuncValueStore(pass, key, kv.Value, localErrs, localFacts, result)
+						}
+					}
+				}
+			}
+		case *ast.CallExpr:
+			calledFn := internal.GetCalledFunction(pass, node)
+			if calledFn == nil {
+				return
+			}
+			params := calledFn.Type().(*types.Signature).Params()
+			for i, arg := range node.Args {
+				if i >= params.Len() {
+					break
+				}
+				for _, field := range paramStores[params.At(i)] {
+					addFuncValueFact(pass, result, field, funcValueErrors(pass, arg, localErrs, localFacts))
+				}
+			}
 		}
 	})
+	return result
 }
 
-// analyzeClosureAssignment handles: handler := func() error { ... }
-func analyzeClosureAssignment(pass *analysis.Pass, stmt *ast.AssignStmt, localErrs *detector.LocalErrors) {
-	for i, rhs := range stmt.Rhs {
-		funcLit, ok := rhs.(*ast.FuncLit)
-		if !ok || i >= len(stmt.Lhs) {
-			continue
+// analyzeFuncValueStore records the errors of a function value stored into target,
+// which is a variable, a struct field, or an element of either.
+func analyzeFuncValueStore(pass *analysis.Pass, target ast.Expr, value ast.Expr, localErrs *detector.LocalErrors, localFacts map[*types.Func]*facts.FunctionErrorsFact, result map[*types.Var]*facts.FunctionErrorsFact) {
+	if ident, ok := target.(*ast.Ident); ok && ident.Name == "_" {
+		return
+	}
+	varObj := internal.FuncValueVar(pass, target)
+	if varObj == nil {
+		return
+	}
+	addFuncValueFact(pass, result, varObj, funcValueErrors(pass, value, localErrs, localFacts))
+}
+
+// funcValueErrors returns the errors of the function value expr when called:
+// the returns of a function literal, the FunctionErrorsFact of a referenced
+// function or method value, or the FuncResultErrorsFact of a call returning the function.
+// For a slice, array or map literal of functions it is the union over the elements.
+// It returns nil for other expressions.
+func funcValueErrors(pass *analysis.Pass, expr ast.Expr, localErrs *detector.LocalErrors, localFacts map[*types.Func]*facts.FunctionErrorsFact) *facts.FunctionErrorsFact {
+	var obj types.Object
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		t := pass.TypesInfo.TypeOf(e)
+		if t == nil {
+			return nil
 		}
-		ident, ok := stmt.Lhs[i].(*ast.Ident)
-		if !ok || ident.Name == "_" {
-			continue
+		switch t.Underlying().(type) {
+		case *types.Slice, *types.Array, *types.Map:
+		default:
+			return nil
 		}
-		obj := pass.TypesInfo.Defs[ident]
-		if obj == nil {
-			obj = pass.TypesInfo.Uses[ident]
+		fact := &facts.FunctionErrorsFact{}
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if elemFact := funcValueErrors(pass, elt, localErrs, localFacts); elemFact != nil {
+				fact.Merge(elemFact)
+			}
 		}
-		if varObj, ok := obj.(*types.Var); ok {
-			analyzeAndExportClosureFact(pass, funcLit, varObj, localErrs)
+		return fact
+	case *ast.CallExpr:
+		calledFn := internal.GetCalledFunction(pass, e)
+		if calledFn == nil {
+			return nil
 		}
-	}
-}
-
-// analyzeClosureValueSpec handles: var handler = func() error { ... }
-func analyzeClosureValueSpec(pass *analysis.Pass, spec *ast.ValueSpec, localErrs *detector.LocalErrors) {
-	for i, value := range spec.Values {
-		funcLit, ok := value.(*ast.FuncLit)
-		if !ok || i >= len(spec.Names) {
-			continue
+		var resultFact facts.FuncResultErrorsFact
+		if !pass.ImportObjectFact(calledFn, &resultFact) {
+			return nil
 		}
-		ident := spec.Names[i]
-		if ident.Name == "_" {
-			continue
+		return &facts.FunctionErrorsFact{Errors: resultFact.Errors}
+	case *ast.FuncLit:
+		tv := pass.TypesInfo.Types[e]
+		if !tv.IsValue() {
+			return nil
+		}
+		sig, ok := tv.Type.(*types.Signature)
+		if !ok {
+			return nil
 		}
-		if varObj, ok := pass.TypesInfo.Defs[ident].(*types.Var); ok {
-			analyzeAndExportClosureFact(pass, funcLit, varObj, localErrs)
+		errorPositions := internal.FindErrorReturnPositions(sig)
+		if len(errorPositions) == 0 {
+			return nil
 		}
+		fact := &facts.FunctionErrorsFact{}
+		analyzeReturns(pass, e.Body, errorPositions, localErrs, fact, localFacts)
+		return fact
+	case *ast.Ident:
+		obj = pass.TypesInfo.Uses[e]
+	case *ast.SelectorExpr:
+		obj = pass.TypesInfo.Uses[e.Sel]
 	}
-}
 
-// analyzeAndExportClosureFact analyzes a function literal for errors and exports a FunctionErrorsFact
-// attached to the given variable.
-func analyzeAndExportClosureFact(pass *analysis.Pass, funcLit *ast.FuncLit, varObj *types.Var, localErrs *detector.LocalErrors) {
-	tv := pass.TypesInfo.Types[funcLit]
-	if !tv.IsValue() {
-		return
-	}
-	sig, ok := tv.Type.(*types.Signature)
+	fn, ok := obj.(*types.Func)
 	if !ok {
+		return nil
+	}
+	fact := &facts.FunctionErrorsFact{}
+	found := pass.ImportObjectFact(fn, fact)
+	if localFact, ok := localFacts[fn]; ok && !found {
+		fact.Merge(localFact)
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return fact
+}
+
+// addFuncValueFact merges fact into the errors recorded for varObj in result.
+func addFuncValueFact(pass *analysis.Pass, result map[*types.Var]*facts.FunctionErrorsFact, varObj *types.Var, fact *facts.FunctionErrorsFact) {
+	if fact == nil || len(fact.Errors) == 0 || varObj.Pkg() != pass.Pkg {
 		return
 	}
-	errorPositions := internal.FindErrorReturnPositions(sig)
-	if len(errorPositions) == 0 {
+	if existing, ok := result[varObj]; ok {
+		existing.Merge(fact)
 		return
 	}
+	result[varObj] = fact
+}
 
-	fact := &facts.FunctionErrorsFact{}
-	analyzeReturns(pass, funcLit.Body, errorPositions, localErrs, fact, nil)
+// findParamFieldStores finds function-typed parameters that their function stores into
+// a struct field of this package, such as r.handlers[path] = h or route{handler: h}.
+// Function values passed for such a parameter flow into the field.
+func findParamFieldStores(pass *analysis.Pass) map[*types.Var][]*types.Var {
+	stores := make(map[*types.Var][]*types.Var)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			params := make(map[*types.Var]bool)
+			sigParams := fn.Type().(*types.Signature).Params()
+			for i := 0; i < sigParams.Len(); i++ {
+				if _, ok := sigParams.At(i).Type().Underlying().(*types.Signature); ok {
+					params[sigParams.At(i)] = true
+				}
+			}
+			if len(params) == 0 {
+				continue
+			}
 
-	if len(fact.Errors) > 0 {
-		pass.ExportObjectFact(varObj, fact)
+			record := func(target, value ast.Expr) {
+				ident, ok := ast.Unparen(value).(*ast.Ident)
+				if !ok {
+					return
+				}
+				param, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+				if !ok || !params[param] {
+					return
+				}
+				if field := internal.FuncValueVar(pass, target); field != nil && field.IsField() && field.Pkg() == pass.Pkg {
+					stores[param] = append(stores[param], field)
+				}
+			}
+			ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.AssignStmt:
+					if len(node.Lhs) == len(node.Rhs) {
+						for i := range node.Rhs {
+							record(node.Lhs[i], node.Rhs[i])
+						}
+					}
+				case *ast.KeyValueExpr:
+					if key, ok := node.Key.(*ast.Ident); ok {
+						record(key, node.Value)
+					}
+				}
+				return true
+			})
+		}
 	}
+	return stores
 }
 
 // ComputeInterfaceMethodFacts computes and exports InterfaceMethodFact, ParameterFlowFact,
 // FunctionParamCallFlowFact, and ParameterCheckedErrorsFact for each interface method in the package.
-// It collects errors from all known implementations.
+// It collects errors from all known implementations, unless the method declares its errors
+// with //goexhauerrors:returns.
 // For ParameterFlowFact, FunctionParamCallFlowFact, and ParameterCheckedErrorsFact,
 // intersection semantics is used (a fact is only exported if ALL implementations agree).
 func ComputeInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*types.Func]*facts.FunctionErrorsFact, localParamFlowFacts map[*types.Func]*facts.ParameterFlowFact, localCallFlowFacts map[*types.Func]*facts.FunctionParamCallFlowFact, impls *internal.InterfaceImplementations) {
@@ -495,10 +772,14 @@ func ComputeInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*types.Func
 		for i := 0; i < ifaceType.NumMethods(); i++ {
 			ifaceMethod := ifaceType.Method(i)
 
-			// Skip methods from embedded interfaces (they belong to other packages)
+			// Skip methods from embedded interfaces: the facts of a method are computed
+			// once, from the implementations of the interface declaring it
 			if ifaceMethod.Pkg() != pass.Pkg {
 				continue
 			}
+			if declaring := internal.DeclaringInterface(ifaceMethod); declaring != nil && declaring != typeName {
+				continue
+			}
 
 			fact := &facts.InterfaceMethodFact{}
 			var allParamFlowFacts []*facts.ParameterFlowFact
@@ -561,6 +842,12 @@ func ComputeInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*types.Func
 				allCheckedFacts = append(allCheckedFacts, cf)
 			}
 
+			// A declared contract takes precedence over the union of the implementations
+			var contract facts.ErrorContractFact
+			if pass.ImportObjectFact(ifaceMethod, &contract) {
+				fact = &facts.InterfaceMethodFact{Errors: contract.Errors}
+			}
+
 			// Export InterfaceMethodFact (union of errors)
 			if len(fact.Errors) > 0 {
 				pass.ExportObjectFact(ifaceMethod, fact)
@@ -593,34 +880,67 @@ func ComputeInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*types.Func
 	}
 }
 
-// ComputeImportedInterfaceMethodFacts computes InterfaceMethodFact and
-// FunctionParamCallFlowFact for interfaces defined in imported packages,
-// using implementations found in the current package.
-// This handles the DI pattern where the interface is in package A (e.g., domain),
-// the implementation is in package B (e.g., infra), and the caller is in package C
-// (e.g., usecase) which imports A but not B. By storing facts in a global store
-// from B, C can later look them up even without importing B.
-func ComputeImportedInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*types.Func]*facts.FunctionErrorsFact, localCallFlowFacts map[*types.Func]*facts.FunctionParamCallFlowFact, impls *internal.InterfaceImplementations) {
-	for _, imp := range pass.Pkg.Imports() {
+// ComputeImportedInterfaceMethodFacts handles interfaces defined in imported packages
+// that are implemented in the current package. This is the DI pattern where the
+// interface is in package A (e.g., domain), the implementation is in package B
+// (e.g., infra), and the caller is in package C (e.g., usecase).
+//
+// Interfaces are taken from all transitively imported packages, so an implementation of
+// an interface two imports away is found as well. Implementing types include types
+// declared inside functions and types that satisfy the interface only through methods
+// promoted from embedded fields.
+//
+// Each package-level implementing type gets an ImplementsFact, so that any package importing B,
+// directly or transitively, can resolve A's interface methods to B's implementations.
+// When C does not import B at all, facts cannot reach it; the union of errors and the
+// intersection of call flows are then recorded in the implementation index instead,
+// which a later run reads back with -implIndex.
+func ComputeImportedInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*types.Func]*facts.FunctionErrorsFact, localCallFlowFacts map[*types.Func]*facts.FunctionParamCallFlowFact, impls *internal.InterfaceImplementations) error {
+	implemented := make(map[*types.TypeName]*facts.ImplementsFact)
+	var implementedOrder []*types.TypeName
+	index := make(implindex.Index)
+
+	for _, imp := range impls.Imports() {
 		impScope := imp.Scope()
 		for _, name := range impScope.Names() {
 			obj := impScope.Lookup(name)
 			typeName, ok := obj.(*types.TypeName)
-			if !ok {
+			if !ok || !typeName.Exported() {
 				continue
 			}
 
 			ifaceType, ok := typeName.Type().Underlying().(*types.Interface)
-			if !ok {
+			if !ok || !internal.HasErrorReturningMethod(ifaceType) {
 				continue
 			}
 
-			// Find implementations of this imported interface in the current package
-			implementingTypes := impls.GetImplementingTypes(ifaceType)
+			// Find implementations of this imported interface defined in the current package
+			var implementingTypes []*types.Named
+			for _, concreteType := range impls.GetImplementingTypes(ifaceType) {
+				if concreteType.Obj().Pkg() == pass.Pkg {
+					implementingTypes = append(implementingTypes, concreteType)
+				}
+			}
 			if len(implementingTypes) == 0 {
 				continue
 			}
 
+			ifaceKey := imp.Path() + "." + typeName.Name()
+			for _, concreteType := range implementingTypes {
+				tn := concreteType.Obj()
+				if tn.Parent() != pass.Pkg.Scope() {
+					// Function-local types cannot be named by other packages
+					continue
+				}
+				fact, ok := implemented[tn]
+				if !ok {
+					fact = &facts.ImplementsFact{}
+					implemented[tn] = fact
+					implementedOrder = append(implementedOrder, tn)
+				}
+				fact.Interfaces = append(fact.Interfaces, ifaceKey)
+			}
+
 			for i := 0; i < ifaceType.NumMethods(); i++ {
 				ifaceMethod := ifaceType.Method(i)
 
@@ -658,21 +978,43 @@ func ComputeImportedInterfaceMethodFacts(pass *analysis.Pass, localFacts map[*ty
 					allCallFlowFacts = append(allCallFlowFacts, pcf)
 				}
 
-				key := facts.InterfaceMethodKey(imp.Path(), typeName.Name(), ifaceMethod.Name())
-
-				if len(fact.Errors) > 0 {
-					// Store in global store so callers that don't import this package can find it
-					facts.MergeInterfaceMethodFact(key, fact)
+				entry := &implindex.Entry{Errors: fact.Errors}
+				if intersected := facts.IntersectFunctionParamCallFlowFacts(allCallFlowFacts); intersected != nil {
+					entry.CallFlows = intersected.CallFlows
 				}
-
-				// Store FunctionParamCallFlowFact in global store (intersection semantics)
-				if len(allCallFlowFacts) > 0 {
-					intersected := facts.IntersectFunctionParamCallFlowFacts(allCallFlowFacts)
-					facts.MergeCallFlowFact(key, intersected)
+				// Methods inherited by embedding are recorded under the declaring interface,
+				// merged with the entries of the other interfaces embedding it
+				key := internal.InterfaceMethodKey(typeName, ifaceMethod)
+				if existing, ok := index[key]; ok {
+					entry = mergeIndexEntries(existing, entry)
 				}
+				index[key] = entry
 			}
 		}
 	}
+
+	for _, tn := range implementedOrder {
+		pass.ExportObjectFact(tn, implemented[tn])
+	}
+
+	return implindex.Write(pass.Pkg.Path(), index)
+}
+
+// mergeIndexEntries merges two index entries of the same interface method: the union of
+// their errors and the intersection of their call flows.
+func mergeIndexEntries(a, b *implindex.Entry) *implindex.Entry {
+	fact := &facts.InterfaceMethodFact{}
+	fact.AddErrors(a.Errors)
+	fact.AddErrors(b.Errors)
+	merged := &implindex.Entry{Errors: fact.Errors}
+	intersected := facts.IntersectFunctionParamCallFlowFacts([]*facts.FunctionParamCallFlowFact{
+		{CallFlows: a.CallFlows},
+		{CallFlows: b.CallFlows},
+	})
+	if intersected != nil {
+		merged.CallFlows = intersected.CallFlows
+	}
+	return merged
 }
 
 // AnalyzeParameterErrorChecks analyzes all functions to detect errors.Is/As checks
@@ -716,30 +1058,76 @@ func AnalyzeParameterErrorChecks(pass *analysis.Pass) {
 	})
 }
 
-// detectParameterErrorChecks walks a function body and detects errors.Is/As calls
-// on the given error parameters.
+// detectParameterErrorChecks walks a function body and detects errors.Is/As calls,
+// check helper calls and code helper comparisons on the given error parameters.
 func detectParameterErrorChecks(pass *analysis.Pass, body *ast.BlockStmt, errorParams map[*types.Var]int) *facts.ParameterCheckedErrorsFact {
 	fact := &facts.ParameterCheckedErrorsFact{}
 
+	// Visit parameters in signature order, not map order, so that the exported fact
+	// is identical on every run and does not invalidate cached results of importers.
+	params := make([]*types.Var, 0, len(errorParams))
+	for paramVar := range errorParams {
+		params = append(params, paramVar)
+	}
+	sort.Slice(params, func(i, j int) bool {
+		return errorParams[params[i]] < errorParams[params[j]]
+	})
+
+	// markCodeComparison records the errors covered by comparing a code helper's result
+	// with a constant (apperr.Code(err) == apperr.CodeNotFound)
+	markCodeComparison := func(call, value ast.Expr) {
+		arg, errs := internal.LookupCodeComparison(pass, call, value)
+		if arg == nil {
+			return
+		}
+		for _, paramVar := range params {
+			if internal.ReferencesVariable(pass, arg, paramVar) {
+				for _, errInfo := range errs {
+					fact.AddCheck(errorParams[paramVar], errInfo)
+				}
+			}
+		}
+	}
+
 	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			if node.Op == token.EQL || node.Op == token.NEQ {
+				markCodeComparison(node.X, node.Y)
+				markCodeComparison(node.Y, node.X)
+			}
+		case *ast.SwitchStmt:
+			if node.Tag != nil {
+				for _, clause := range node.Body.List {
+					for _, expr := range clause.(*ast.CaseClause).List {
+						markCodeComparison(node.Tag, expr)
+					}
+				}
+			}
+		}
+
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
 		}
 
 		if internal.IsErrorsIsCall(pass, call) && len(call.Args) >= 2 {
-			for paramVar, paramIdx := range errorParams {
+			for _, paramVar := range params {
+				paramIdx := errorParams[paramVar]
 				if internal.ReferencesVariable(pass, call.Args[0], paramVar) {
-					errInfo := internal.ExtractErrorInfoFromExpr(pass, call.Args[1])
-					if errInfo != nil {
-						fact.AddCheck(paramIdx, *errInfo)
+					for _, target := range call.Args[1:] {
+						errInfo := internal.ExtractErrorInfoFromExpr(pass, target)
+						if errInfo != nil {
+							fact.AddCheck(paramIdx, *errInfo)
+						}
 					}
 				}
 			}
 		}
 
 		if internal.IsErrorsAsCall(pass, call) && len(call.Args) >= 2 {
-			for paramVar, paramIdx := range errorParams {
+			for _, paramVar := range params {
+				paramIdx := errorParams[paramVar]
 				if internal.ReferencesVariable(pass, call.Args[0], paramVar) {
 					errInfo := internal.ExtractErrorInfoFromAsTarget(pass, call.Args[1])
 					if errInfo != nil {
@@ -749,6 +1137,17 @@ func detectParameterErrorChecks(pass *analysis.Pass, body *ast.BlockStmt, errorP
 			}
 		}
 
+		if checkFact := internal.LookupCheckFunction(pass, call); checkFact != nil && checkFact.ParamIndex < len(call.Args) {
+			for _, paramVar := range params {
+				paramIdx := errorParams[paramVar]
+				if internal.ReferencesVariable(pass, call.Args[checkFact.ParamIndex], paramVar) {
+					for _, errInfo := range checkFact.Errors {
+						fact.AddCheck(paramIdx, errInfo)
+					}
+				}
+			}
+		}
+
 		return true
 	})
 
@@ -757,3 +1156,300 @@ func detectParameterErrorChecks(pass *analysis.Pass, body *ast.BlockStmt, errorP
 	}
 	return fact
 }
+
+// AnalyzeCheckFunctions finds functions annotated with //goexhauerrors:checks and
+// exports a CheckFunctionFact for each of them.
+// This handles helpers like: //goexhauerrors:checks ErrNotFound
+//
+//	func IsNotFound(err error) bool { ... }
+//
+// Arguments are error names resolved in the package scope, or qualified with an
+// imported package name (e.g., storage.ErrNotFound). A helper returning a code lists
+// the errors of each constant its result is compared with: CodeNotFound=ErrNotFound|ErrGone.
+func AnalyzeCheckFunctions(pass *analysis.Pass, localErrs *detector.LocalErrors) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			directive, ok := internal.FindDirective(funcDecl.Doc, "checks")
+			if !ok {
+				continue
+			}
+			fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			paramIndex := -1
+			params := fn.Type().(*types.Signature).Params()
+			for i := 0; i < params.Len(); i++ {
+				if internal.IsErrorType(params.At(i).Type()) {
+					paramIndex = i
+					break
+				}
+			}
+			if paramIndex < 0 {
+				pass.Reportf(directive.Pos, "goexhauerrors:checks requires %s to have an error parameter", fn.Name())
+				continue
+			}
+
+			fact := &facts.CheckFunctionFact{ParamIndex: paramIndex}
+			for _, arg := range directive.Args {
+				// CodeNotFound=ErrNotFound|ErrGone covers the errors of Code(err) == CodeNotFound
+				valueName, names, isValue := strings.Cut(arg, "=")
+				value := ""
+				if isValue {
+					value = resolveDirectiveConst(pass, file, valueName)
+					if value == "" {
+						pass.Reportf(directive.Pos, "unknown constant %s in goexhauerrors:checks directive", valueName)
+						continue
+					}
+				} else {
+					names = arg
+				}
+				for _, name := range strings.Split(names, "|") {
+					errInfo := resolveDirectiveError(pass, file, name, localErrs)
+					if errInfo == nil {
+						pass.Reportf(directive.Pos, "unknown error %s in goexhauerrors:checks directive", name)
+						continue
+					}
+					if isValue {
+						fact.AddValueError(value, *errInfo)
+					} else {
+						fact.AddError(*errInfo)
+					}
+				}
+			}
+			if len(fact.Errors) > 0 || len(fact.Values) > 0 {
+				pass.ExportObjectFact(fn, fact)
+			}
+		}
+	}
+}
+
+// AnalyzeMockTypes finds type declarations annotated with //goexhauerrors:mock and
+// exports a MockFact for each of them, so that every package excludes them from
+// the error sets of the interfaces they implement.
+func AnalyzeMockTypes(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && !genDecl.Lparen.IsValid() {
+					doc = genDecl.Doc
+				}
+				if _, ok := internal.FindDirective(doc, "mock"); !ok {
+					continue
+				}
+				if tn, ok := pass.TypesInfo.Defs[typeSpec.Name].(*types.TypeName); ok {
+					pass.ExportObjectFact(tn, &facts.MockFact{})
+				}
+			}
+		}
+	}
+}
+
+// AnalyzeErrorContracts finds interface methods annotated with //goexhauerrors:returns
+// and exports an ErrorContractFact for each of them.
+// This handles contracts like:
+//
+//	type Repository interface {
+//		//goexhauerrors:returns ErrNotFound
+//		Find(id string) error
+//	}
+//
+// Arguments are resolved like those of //goexhauerrors:checks.
+func AnalyzeErrorContracts(pass *analysis.Pass, localErrs *detector.LocalErrors) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				ifaceType, ok := spec.(*ast.TypeSpec).Type.(*ast.InterfaceType)
+				if !ok {
+					continue
+				}
+				for _, field := range ifaceType.Methods.List {
+					if len(field.Names) == 0 {
+						continue // embedded interface
+					}
+					directive, ok := internal.FindDirective(field.Doc, "returns")
+					if !ok {
+						continue
+					}
+					method, ok := pass.TypesInfo.Defs[field.Names[0]].(*types.Func)
+					if !ok {
+						continue
+					}
+
+					fact := &facts.ErrorContractFact{}
+					for _, name := range directive.Args {
+						errInfo := resolveDirectiveError(pass, file, name, localErrs)
+						if errInfo == nil {
+							pass.Reportf(directive.Pos, "unknown error %s in goexhauerrors:returns directive", name)
+							continue
+						}
+						fact.AddError(*errInfo)
+					}
+					pass.ExportObjectFact(method, fact)
+				}
+			}
+		}
+	}
+}
+
+// VerifyErrorContracts checks the methods of this package that implement an interface
+// method with an ErrorContractFact. An error the contract does not declare is reported at
+// the return statement it comes from, or at the method if it cannot be attributed to one
+// (e.g., it was traced through a local variable).
+func VerifyErrorContracts(pass *analysis.Pass, localErrs *detector.LocalErrors, localFacts map[*types.Func]*facts.FunctionErrorsFact, impls *internal.InterfaceImplementations) {
+	funcDecls := make(map[*types.Func]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				if fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+					funcDecls[fn] = funcDecl
+				}
+			}
+		}
+	}
+
+	// Interfaces without an implementation among the candidate types are not implemented here
+	for _, typeName := range impls.ImplementedInterfaces() {
+		ifaceType := typeName.Type().Underlying().(*types.Interface)
+		for i := 0; i < ifaceType.NumMethods(); i++ {
+			ifaceMethod := ifaceType.Method(i)
+			var contract facts.ErrorContractFact
+			if !pass.ImportObjectFact(ifaceMethod, &contract) {
+				continue
+			}
+			for _, concreteType := range impls.GetImplementingTypes(ifaceType) {
+				method := internal.FindMethodImplementation(concreteType, ifaceMethod)
+				if method == nil || funcDecls[method] == nil || localFacts[method] == nil {
+					continue
+				}
+				contractName := typeName.Name() + "." + ifaceMethod.Name()
+				verifyErrorContract(pass, funcDecls[method], contractName, &contract, localErrs, localFacts)
+			}
+		}
+	}
+}
+
+// verifyErrorContract reports the errors of funcDecl that contract does not declare.
+func verifyErrorContract(pass *analysis.Pass, funcDecl *ast.FuncDecl, contractName string, contract *facts.ErrorContractFact, localErrs *detector.LocalErrors, localFacts map[*types.Func]*facts.FunctionErrorsFact) {
+	fn := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+	sig := fn.Type().(*types.Signature)
+	errorPositions := internal.FindErrorReturnPositions(sig)
+
+	undeclared := make(map[string]bool)
+	for _, errInfo := range localFacts[fn].Errors {
+		if !facts.ContainsErrorInfo(contract.Errors, errInfo) {
+			undeclared[errInfo.Key()] = true
+		}
+	}
+	if len(undeclared) == 0 {
+		return
+	}
+
+	attributed := make(map[string]bool)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok {
+			return true
+		}
+		retFact := &facts.FunctionErrorsFact{}
+		if len(ret.Results) == 1 && sig.Results().Len() > 1 {
+			analyzeErrorExpr(pass, ret.Results[0], localErrs, retFact, localFacts)
+		} else {
+			for _, pos := range errorPositions {
+				if pos < len(ret.Results) {
+					analyzeErrorExpr(pass, ret.Results[pos], localErrs, retFact, localFacts)
+				}
+			}
+		}
+		for _, errInfo := range retFact.Errors {
+			if undeclared[errInfo.Key()] {
+				attributed[errInfo.Key()] = true
+				pass.Reportf(ret.Pos(), "%s is not declared by the goexhauerrors:returns contract of %s", errInfo.Key(), contractName)
+			}
+		}
+		return true
+	})
+
+	for _, errInfo := range localFacts[fn].Errors {
+		if undeclared[errInfo.Key()] && !attributed[errInfo.Key()] {
+			pass.Reportf(funcDecl.Name.Pos(), "%s is not declared by the goexhauerrors:returns contract of %s", errInfo.Key(), contractName)
+		}
+	}
+}
+
+// resolveDirectiveConst resolves a constant name used in a directive to its fully
+// qualified name (pkg/path.Name), or "" if it does not name a constant. Names are
+// resolved like those of resolveDirectiveError.
+func resolveDirectiveConst(pass *analysis.Pass, file *ast.File, name string) string {
+	scope := pass.Pkg.Scope()
+	if qualifier, rest, ok := strings.Cut(name, "."); ok {
+		pkgName, ok := pass.TypesInfo.Scopes[file].Lookup(qualifier).(*types.PkgName)
+		if !ok {
+			return ""
+		}
+		scope = pkgName.Imported().Scope()
+		name = rest
+	}
+
+	c, ok := scope.Lookup(name).(*types.Const)
+	if !ok {
+		return ""
+	}
+	return c.Pkg().Path() + "." + c.Name()
+}
+
+// resolveDirectiveError resolves an error name used in a directive to its ErrorInfo.
+// Unqualified names are looked up in the package scope; qualified names (pkg.Name)
+// are looked up in the scope of the imported package with that name in the file.
+func resolveDirectiveError(pass *analysis.Pass, file *ast.File, name string, localErrs *detector.LocalErrors) *facts.ErrorInfo {
+	scope := pass.Pkg.Scope()
+	if qualifier, rest, ok := strings.Cut(name, "."); ok {
+		pkgName, ok := pass.TypesInfo.Scopes[file].Lookup(qualifier).(*types.PkgName)
+		if !ok {
+			return nil
+		}
+		scope = pkgName.Imported().Scope()
+		name = rest
+	}
+
+	obj := scope.Lookup(name)
+	if obj == nil {
+		return nil
+	}
+
+	var errorFact facts.ErrorFact
+	if pass.ImportObjectFact(obj, &errorFact) {
+		return &facts.ErrorInfo{PkgPath: errorFact.PkgPath, Name: errorFact.Name}
+	}
+
+	// Unexported local errors have no exported fact
+	switch o := obj.(type) {
+	case *types.Var:
+		if localErrs.Vars[o] {
+			return &facts.ErrorInfo{PkgPath: pass.Pkg.Path(), Name: o.Name()}
+		}
+	case *types.TypeName:
+		if localErrs.Types[o] {
+			return &facts.ErrorInfo{PkgPath: pass.Pkg.Path(), Name: o.Name()}
+		}
+	}
+	return nil
+}
@@ -1,9 +1,18 @@
 package goexhauerrors_test
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/errset"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -43,12 +52,144 @@ func TestAnalyzer(t *testing.T) {
 		"crosspkgunexported/caller",
 		"crosspkgdi/domain",
 		"crosspkgdi/infra",
-		"crosspkgdi/usecase",
+		"crosspkgdi/wire",
+		"crosspkgdi/usecasenoindex",
+		"crosspkgdi/app",
+		"crosspkgdi/cached",
+		"crosspkgdi/cachedapp",
+		"contract",
+		"contract/impl",
+		"contract/caller",
+		"funcfield",
+		"funcfield/caller",
+		"variadicflow",
+		"variadicflow/caller",
+		"hierarchy",
+		"hierarchy/caller",
+		"asform",
+		"asform/caller",
+		"iterator",
+		"iterator/caller",
+		"typeparam",
+		"typeparam/caller",
+		"embedding",
+		"embedding/caller",
+		"namedresult",
+		"errresult",
+		"errresult/caller",
+		"sentinelalias/storage",
+		"sentinelalias/facade",
+		"sentinelalias/caller",
+		"initsentinel",
+		"initsentinel/caller",
 		"ifacehigherorder",
 		"compositelit",
+		"checkhelper/apperr",
+		"checkhelper/caller",
+		"errlib",
+		"unwrap/ops",
+		"unwrap/caller",
 	)
 }
 
+func TestAnalyzerWithImplIndex(t *testing.T) {
+	testdata := analysistest.TestData()
+	index := t.TempDir()
+
+	// First run: record the implementations of crosspkgdi/infra
+	if err := goexhauerrors.Analyzer.Flags.Set("writeImplIndex", index); err != nil {
+		t.Fatalf("failed to set writeImplIndex flag: %v", err)
+	}
+	analysistest.Run(t, testdata, goexhauerrors.Analyzer, "crosspkgdi/infra")
+	_ = goexhauerrors.Analyzer.Flags.Set("writeImplIndex", "")
+
+	// Second run: usecase does not import infra and resolves Repository through the index
+	if err := goexhauerrors.Analyzer.Flags.Set("implIndex", index); err != nil {
+		t.Fatalf("failed to set implIndex flag: %v", err)
+	}
+	defer func() {
+		_ = goexhauerrors.Analyzer.Flags.Set("implIndex", "")
+	}()
+	analysistest.Run(t, testdata, goexhauerrors.Analyzer, "crosspkgdi/usecase")
+}
+
+// TestAnalyzerSeparateProcesses checks that cross-package interface diagnostics do not
+// depend on the driver. go vet analyzes every package in its own process, in an order
+// of its choosing, and loads the facts of dependencies written by earlier processes.
+// Implementations in packages the caller only imports indirectly need the
+// implementation index there, so the packages are vetted twice as documented.
+func TestAnalyzerSeparateProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the analyzer and runs go vet")
+	}
+	testdata, err := filepath.Abs(analysistest.TestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgs := []string{
+		"crosspkgdi/app",
+		"crosspkgdi/wire",
+		"crosspkgdi/infra",
+		"crosspkgdi/domain",
+		"crosspkgdi/cachedapp",
+		"crosspkgdi/cached",
+		"crosspkgiface/caller",
+		"crosspkgiface/impl",
+		"crosspkgiface/iface",
+	}
+
+	var want []string
+	for _, result := range analysistest.Run(t, testdata, goexhauerrors.Analyzer, pkgs...) {
+		for _, d := range result.Diagnostics {
+			posn := result.Pass.Fset.Position(d.Pos)
+			rel, _ := filepath.Rel(filepath.Join(testdata, "src"), posn.Filename)
+			want = append(want, fmt.Sprintf("%s:%d: %s", filepath.ToSlash(rel), posn.Line, d.Message))
+		}
+	}
+	sort.Strings(want)
+	if len(want) == 0 {
+		t.Fatal("expected diagnostics from cross-package interface calls")
+	}
+
+	tmp := t.TempDir()
+	vettool := filepath.Join(tmp, "goexhauerrors")
+	build := exec.Command("go", "build", "-o", vettool, "..")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	vet := func(flags ...string) []string {
+		args := append([]string{"vet", "-vettool=" + vettool}, flags...)
+		cmd := exec.Command("go", append(args, pkgs...)...)
+		cmd.Dir = testdata
+		cmd.Env = append(os.Environ(), "GO111MODULE=off", "GOPATH="+testdata, "GOFLAGS=")
+		out, _ := cmd.CombinedOutput() // go vet exits with status 1 when it reports diagnostics
+
+		var got []string
+		for _, line := range strings.Split(string(out), "\n") {
+			m := vetDiagnostic.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			got = append(got, fmt.Sprintf("%s:%s: %s", m[1], m[2], m[3]))
+		}
+		sort.Strings(got)
+		if len(got) == 0 {
+			t.Logf("go vet output:\n%s", out)
+		}
+		return got
+	}
+
+	index := filepath.Join(tmp, "index")
+	vet("-writeImplIndex=" + index)
+	if got := vet("-implIndex=" + index); !reflect.DeepEqual(got, want) {
+		t.Errorf("go vet diagnostics differ from a single run:\ngo vet: %v\nsingle: %v", got, want)
+	}
+}
+
+// vetDiagnostic matches a diagnostic printed by go vet: src/pkg/file.go:line:col: message
+var vetDiagnostic = regexp.MustCompile(`^src/(.+\.go):(\d+):\d+: (.+)$`)
+
 func TestAnalyzerWithIgnorePackages(t *testing.T) {
 	testdata := analysistest.TestData()
 
@@ -68,3 +209,142 @@ func TestAnalyzerWithIgnorePackages(t *testing.T) {
 		"useignored",
 	)
 }
+
+func TestAnalyzerWithMocks(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	if err := goexhauerrors.Analyzer.Flags.Set("mockFiles", "mock_*.go"); err != nil {
+		t.Fatalf("failed to set mockFiles flag: %v", err)
+	}
+	if err := goexhauerrors.Analyzer.Flags.Set("mockPackages", "mockimpl/mocks"); err != nil {
+		t.Fatalf("failed to set mockPackages flag: %v", err)
+	}
+
+	defer func() {
+		_ = goexhauerrors.Analyzer.Flags.Set("mockFiles", "")
+		_ = goexhauerrors.Analyzer.Flags.Set("mockPackages", "")
+	}()
+
+	// Mocks must not contribute to the error set of mockimpl.Repository
+	analysistest.Run(t, testdata, goexhauerrors.Analyzer,
+		"mockimpl",
+		"mockimpl/mocks",
+		"mockimpl/caller",
+	)
+}
+
+func TestAnalyzerWithCheckFunctions(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	if err := goexhauerrors.Analyzer.Flags.Set("checkFunctions",
+		"checkhelperconfig.IsNotFound=checkhelperconfig.ErrNotFound,checkhelperconfig.Classifier.IsTimeout=checkhelperconfig.ErrTimeout,checkhelperconfig.StatusOf=checkhelperconfig.StatusTimeout=checkhelperconfig.ErrTimeout"); err != nil {
+		t.Fatalf("failed to set checkFunctions flag: %v", err)
+	}
+
+	defer func() {
+		_ = goexhauerrors.Analyzer.Flags.Set("checkFunctions", "")
+	}()
+
+	analysistest.Run(t, testdata, goexhauerrors.Analyzer, "checkhelperconfig")
+}
+
+func TestAnalyzerWithErrorLibraryFunctions(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	if err := goexhauerrors.Analyzer.Flags.Set("wrapperFunctions", "errlibconfig.Annotate=1"); err != nil {
+		t.Fatalf("failed to set wrapperFunctions flag: %v", err)
+	}
+	if err := goexhauerrors.Analyzer.Flags.Set("checkerFunctions", "errlibconfig.Matches=Is"); err != nil {
+		t.Fatalf("failed to set checkerFunctions flag: %v", err)
+	}
+
+	defer func() {
+		_ = goexhauerrors.Analyzer.Flags.Set("wrapperFunctions", "")
+		_ = goexhauerrors.Analyzer.Flags.Set("checkerFunctions", "")
+	}()
+
+	analysistest.Run(t, testdata, goexhauerrors.Analyzer, "errlibconfig")
+}
+
+func TestAnalyzerFlattenedErrorFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, goexhauerrors.Analyzer, "flattened")
+}
+
+func TestAnalyzerWrapUnsafeCheckFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, goexhauerrors.Analyzer, "wrapunsafe")
+}
+
+func TestDiffAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	snapshot := t.TempDir()
+
+	if err := goexhauerrors.DiffAnalyzer.Flags.Set("baseline", filepath.Join(testdata, "errsetdiff_baseline")); err != nil {
+		t.Fatalf("failed to set baseline flag: %v", err)
+	}
+	if err := goexhauerrors.DiffAnalyzer.Flags.Set("snapshot", snapshot); err != nil {
+		t.Fatalf("failed to set snapshot flag: %v", err)
+	}
+
+	defer func() {
+		_ = goexhauerrors.DiffAnalyzer.Flags.Set("baseline", "")
+		_ = goexhauerrors.DiffAnalyzer.Flags.Set("snapshot", "")
+	}()
+
+	analysistest.Run(t, testdata, goexhauerrors.DiffAnalyzer, "errsetdiff")
+
+	got, err := errset.Read(snapshot, "errsetdiff")
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	want := map[string][]string{
+		"Added":           {"errsetdiff.ErrGone"},
+		"Delete":          {"errsetdiff.ErrNotFound"},
+		"Find":            {"errsetdiff.ErrNotFound", "errsetdiff.ErrTimeout"},
+		"Repository.Save": {"errsetdiff.ErrNotFound", "errsetdiff.ErrTimeout"},
+		"Store.Get":       {"errsetdiff.ErrGone"},
+		"Unchanged":       {"errsetdiff.ErrNotFound"},
+	}
+	if got == nil || !reflect.DeepEqual(got.Functions, want) {
+		t.Errorf("snapshot = %+v, want functions %v", got, want)
+	}
+}
+
+// TestDiffAnalyzerSameDirectory compares against and refreshes a snapshot in one run.
+// The baseline must be read before the snapshot replaces it.
+func TestDiffAnalyzerSameDirectory(t *testing.T) {
+	testdata := analysistest.TestData()
+	dir := t.TempDir()
+
+	data, err := os.ReadFile(filepath.Join(testdata, "errsetdiff_baseline", "errsetdiff.json"))
+	if err != nil {
+		t.Fatalf("failed to read baseline: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "errsetdiff.json"), data, 0o644); err != nil {
+		t.Fatalf("failed to copy baseline: %v", err)
+	}
+
+	if err := goexhauerrors.DiffAnalyzer.Flags.Set("baseline", dir); err != nil {
+		t.Fatalf("failed to set baseline flag: %v", err)
+	}
+	if err := goexhauerrors.DiffAnalyzer.Flags.Set("snapshot", dir); err != nil {
+		t.Fatalf("failed to set snapshot flag: %v", err)
+	}
+
+	defer func() {
+		_ = goexhauerrors.DiffAnalyzer.Flags.Set("baseline", "")
+		_ = goexhauerrors.DiffAnalyzer.Flags.Set("snapshot", "")
+	}()
+
+	// The expectations in errsetdiff report the added and removed errors
+	analysistest.Run(t, testdata, goexhauerrors.DiffAnalyzer, "errsetdiff")
+
+	got, err := errset.Read(dir, "errsetdiff")
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if got == nil || !reflect.DeepEqual(got.Functions["Added"], []string{"errsetdiff.ErrGone"}) {
+		t.Errorf("snapshot = %+v, want it refreshed with the current error sets", got)
+	}
+}
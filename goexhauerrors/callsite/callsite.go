@@ -0,0 +1,44 @@
+// Package callsite defines the call-site index of the whole-program mode.
+//
+// The index maps dynamic call sites (interface method invocations and calls of
+// function values) to the errors of the callees a call graph found for them. It is
+// built by the wholeprogram package and handed to the analyzer of each round; the
+// regular analysis runs without one.
+package callsite
+
+import (
+	"go/token"
+	"strconv"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+)
+
+// Index maps dynamic call sites ("file:line:col" of the call's opening parenthesis)
+// to the errors their possible callees return. A nil Index resolves no call.
+type Index map[string][]facts.ErrorInfo
+
+// Key returns the index key of the call site at pos.
+func Key(pos token.Position) string {
+	return pos.Filename + ":" + strconv.Itoa(pos.Line) + ":" + strconv.Itoa(pos.Column)
+}
+
+// Lookup returns the errors of the possible callees of the dynamic call at pos.
+func (idx Index) Lookup(pos token.Position) []facts.ErrorInfo {
+	if idx == nil {
+		return nil
+	}
+	return idx[Key(pos)]
+}
+
+// KnownErrors returns the keys of all errors in the index.
+// They come from facts of other packages, so they are valid even when the
+// package defining them is not imported by the caller.
+func (idx Index) KnownErrors() []string {
+	var keys []string
+	for _, errs := range idx {
+		for _, errInfo := range errs {
+			keys = append(keys, errInfo.Key())
+		}
+	}
+	return keys
+}
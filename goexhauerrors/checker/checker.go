@@ -1,46 +1,51 @@
 package checker
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"sort"
 
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/callsite"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/implindex"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	ssaanalysis "github.com/YuitoSato/goexhauerrors/goexhauerrors/ssa"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/ssa"
 )
 
 // CallSiteAnalyzer holds context for call site analysis to avoid recomputing expensive data.
 type CallSiteAnalyzer struct {
-	Pass               *analysis.Pass
-	InterfaceImpls     *internal.InterfaceImplementations
-	hadGlobalStoreMiss bool                          // set to true if any interface method lookup missed the global store
-	reported           map[token.Pos]map[string]bool // tracks (callPos, errorKey) already reported to prevent duplicates
+	Pass           *analysis.Pass
+	InterfaceImpls *internal.InterfaceImplementations
+	CallSites      callsite.Index                // callees of dynamic calls, in whole-program mode
+	reported       map[token.Pos]map[string]bool // tracks (callPos, errorKey) already reported to prevent duplicates
+	implementers   map[string][]*types.Named     // interface key -> implementing types of other packages, from ImplementsFact
+	invokeSites    map[token.Pos]*ssa.CallCommon // interface method calls by opening parenthesis, for devirtualization
+	yieldParams    map[*types.Var]int            // yield parameters of iterator functions -> index of their error parameter
+	namedResults   map[*types.Var]bool           // named error results, propagated by a bare return
+	sentinels      *internal.SentinelIndex       // ancestors and visible aliases of sentinels
 }
 
 // CheckCallSites checks all call sites to ensure errors are properly checked.
-func CheckCallSites(pass *analysis.Pass, interfaceImpls *internal.InterfaceImplementations) {
-	checkCallSitesInternal(pass, interfaceImpls, true)
-}
-
-func checkCallSitesInternal(pass *analysis.Pass, interfaceImpls *internal.InterfaceImplementations, registerDeferred bool) {
+func CheckCallSites(pass *analysis.Pass, interfaceImpls *internal.InterfaceImplementations, callSites callsite.Index) {
 	csa := &CallSiteAnalyzer{
 		Pass:           pass,
 		InterfaceImpls: interfaceImpls,
+		CallSites:      callSites,
 		reported:       make(map[token.Pos]map[string]bool),
+		yieldParams:    make(map[*types.Var]int),
+		namedResults:   make(map[*types.Var]bool),
+		sentinels:      internal.NewSentinelIndex(pass),
 	}
 
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	// Collect functions that had global store misses for deferred re-analysis
-	type funcToCheck struct {
-		body         *ast.BlockStmt
-		returnsError bool
-	}
-	var missedFuncs []funcToCheck
-
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 		(*ast.FuncLit)(nil),
@@ -52,12 +57,11 @@ func checkCallSitesInternal(pass *analysis.Pass, interfaceImpls *internal.Interf
 			if node.Body == nil {
 				return
 			}
-			returnsError := funcReturnsError(pass, node)
-			csa.hadGlobalStoreMiss = false
-			csa.checkFunctionBody(node.Body, returnsError)
-			if csa.hadGlobalStoreMiss && registerDeferred {
-				missedFuncs = append(missedFuncs, funcToCheck{node.Body, returnsError})
+			if fn, ok := pass.TypesInfo.Defs[node.Name].(*types.Func); ok {
+				csa.recordYieldParam(fn.Type().(*types.Signature))
+				csa.recordNamedResults(fn.Type().(*types.Signature))
 			}
+			csa.checkFunctionBody(node.Body, funcReturnsError(pass, node))
 
 		case *ast.FuncLit:
 			if node.Body == nil {
@@ -71,47 +75,21 @@ func checkCallSitesInternal(pass *analysis.Pass, interfaceImpls *internal.Interf
 			if !ok {
 				return
 			}
+			csa.recordYieldParam(sig)
+			csa.recordNamedResults(sig)
 			returnsError := false
 			results := sig.Results()
 			for i := 0; i < results.Len(); i++ {
-				if internal.IsErrorType(results.At(i).Type()) {
+				if internal.IsErrorResultType(results.At(i).Type()) {
 					returnsError = true
 					break
 				}
 			}
-			csa.hadGlobalStoreMiss = false
 			csa.checkFunctionBody(node.Body, returnsError)
-			if csa.hadGlobalStoreMiss && registerDeferred {
-				missedFuncs = append(missedFuncs, funcToCheck{node.Body, returnsError})
-			}
 		}
 	})
 
-	// Register deferred re-analysis for functions that had global store misses
-	if len(missedFuncs) > 0 && registerDeferred {
-		capturedPass := pass
-		capturedImpls := interfaceImpls
-		capturedFuncs := missedFuncs
-		capturedReported := csa.reported
-		facts.AddDeferredFunctionCheck(&facts.DeferredFunctionCheck{
-			ReAnalyze: func() bool {
-				reAnalyzer := &CallSiteAnalyzer{
-					Pass:           capturedPass,
-					InterfaceImpls: capturedImpls,
-					reported:       capturedReported,
-				}
-				stillHasMiss := false
-				for _, f := range capturedFuncs {
-					reAnalyzer.hadGlobalStoreMiss = false
-					reAnalyzer.checkFunctionBody(f.body, f.returnsError)
-					if reAnalyzer.hadGlobalStoreMiss {
-						stillHasMiss = true
-					}
-				}
-				return stillHasMiss
-			},
-		})
-	}
+	csa.reportTypedNilReturns()
 }
 
 // funcReturnsError checks if the function returns an error type.
@@ -127,7 +105,7 @@ func funcReturnsError(pass *analysis.Pass, funcDecl *ast.FuncDecl) bool {
 	sig := fn.Type().(*types.Signature)
 	results := sig.Results()
 	for i := 0; i < results.Len(); i++ {
-		if internal.IsErrorType(results.At(i).Type()) {
+		if internal.IsErrorResultType(results.At(i).Type()) {
 			return true
 		}
 	}
@@ -152,7 +130,7 @@ func (csa *CallSiteAnalyzer) checkFunctionBody(body *ast.BlockStmt, canPropagate
 
 	// Report any remaining unchecked errors at end of function
 	for _, state := range states {
-		reportUncheckedErrors(csa.Pass, state, csa.reported)
+		csa.reportUncheckedErrors(state)
 	}
 }
 
@@ -170,7 +148,7 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 	case *ast.AssignStmt:
 		// First, check for errors.Is in RHS expressions (before assignment)
 		for _, rhs := range s.Rhs {
-			collectErrorsIsInExpr(pass, rhs, states)
+			csa.collectErrorsIsInExpr(rhs, states)
 		}
 
 		// Then process assignments
@@ -188,14 +166,14 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 				continue
 			}
 
-			errorVar := findErrorVarInAssignmentWithSig(pass, s, i, sig)
+			errorVar := findErrorVarInAssignmentWithSig(pass, s, i, call, sig)
 			if errorVar == nil {
 				continue
 			}
 
 			// If this variable already has active errors, report unchecked ones
 			if existingState, ok := states[errorVar]; ok {
-				reportUncheckedErrors(pass, existingState, csa.reported)
+				csa.reportUncheckedErrors(existingState)
 			}
 
 			// Set new state for this variable
@@ -208,11 +186,11 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 
 	case *ast.ExprStmt:
 		// Check for errors.Is calls in expression statements
-		collectErrorsIsInExpr(pass, s.X, states)
+		csa.collectErrorsIsInExpr(s.X, states)
 
 	case *ast.IfStmt:
 		// Check condition for errors.Is
-		collectErrorsIsInExpr(pass, s.Cond, states)
+		csa.collectErrorsIsInExpr(s.Cond, states)
 
 		// Process init statement if present
 		if s.Init != nil {
@@ -244,13 +222,18 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 			csa.walkStatementWithScope(s.Init, states, canPropagate)
 		}
 		if s.Tag != nil {
-			collectErrorsIsInExpr(pass, s.Tag, states)
+			csa.collectErrorsIsInExpr(s.Tag, states)
 		}
 
 		// Find tracked error variable used as switch tag (for `switch err { case ErrX: }`)
 		var switchTagVar *types.Var
+		var switchCause internal.WrapperFunc
+		switchesOnVar := false
 		if s.Tag != nil {
-			if ident, ok := s.Tag.(*ast.Ident); ok {
+			// switch errors.Cause(err) { ... } compares the root cause of err
+			tag, cause := internal.UnwrapCauseExpr(pass, s.Tag)
+			switchCause = cause
+			if ident, ok := tag.(*ast.Ident); ok {
 				obj := pass.TypesInfo.Uses[ident]
 				if v, ok := obj.(*types.Var); ok {
 					if _, tracked := states[v]; tracked {
@@ -258,6 +241,7 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 					}
 				}
 			}
+			_, switchesOnVar = ast.Unparen(s.Tag).(*ast.Ident)
 		}
 
 		// Process switch body
@@ -269,15 +253,23 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 
 					// Check case expressions for errors.Is (scoped to caseStates)
 					for _, expr := range cc.List {
-						collectErrorsIsInExpr(pass, expr, caseStates)
+						csa.collectErrorsIsInExpr(expr, caseStates)
+						// switch status.Code(err) { case codes.NotFound: }
+						if s.Tag != nil {
+							csa.tryMarkCodeComparison(s.Tag, expr, caseStates)
+						}
 					}
 					// Check case values as direct comparisons against switch tag
 					if switchTagVar != nil {
 						if state, ok := caseStates[switchTagVar]; ok {
 							for _, expr := range cc.List {
 								errorKey := internal.ExtractErrorKey(pass, expr)
-								if errorKey != "" {
+								if errorKey != "" && causeReaches(state, errorKey, switchCause) {
 									state.checked[errorKey] = true
+									// Each case compares with ==, like err == ErrX
+									if switchesOnVar {
+										csa.reportWrapUnsafeSwitchCase(s, expr, state, errorKey)
+									}
 								}
 							}
 						}
@@ -306,32 +298,37 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 
 		// Find the error variable being type-switched
 		var switchVar *types.Var
+		var switchExpr *ast.TypeAssertExpr
+		var switchCause internal.WrapperFunc
+		switchesOnVar := false
 		if s.Assign != nil {
 			switch assign := s.Assign.(type) {
 			case *ast.ExprStmt:
 				// switch err.(type) { ... }
 				if ta, ok := assign.X.(*ast.TypeAssertExpr); ok {
-					if ident, ok := ta.X.(*ast.Ident); ok {
-						obj := pass.TypesInfo.Uses[ident]
-						if v, ok := obj.(*types.Var); ok {
-							switchVar = v
-						}
-					}
+					switchExpr = ta
 				}
 			case *ast.AssignStmt:
 				// switch v := err.(type) { ... }
 				if len(assign.Rhs) == 1 {
 					if ta, ok := assign.Rhs[0].(*ast.TypeAssertExpr); ok {
-						if ident, ok := ta.X.(*ast.Ident); ok {
-							obj := pass.TypesInfo.Uses[ident]
-							if v, ok := obj.(*types.Var); ok {
-								switchVar = v
-							}
-						}
+						switchExpr = ta
 					}
 				}
 			}
 		}
+		if switchExpr != nil {
+			x, cause := internal.UnwrapCauseExpr(pass, switchExpr.X)
+			switchCause = cause
+			if ident, ok := x.(*ast.Ident); ok {
+				obj := pass.TypesInfo.Uses[ident]
+				if v, ok := obj.(*types.Var); ok {
+					switchVar = v
+				}
+			}
+			// switch errors.Cause(err).(type) compares the root cause; causeReaches leaves out errors Cause does not unwrap
+			_, switchesOnVar = ast.Unparen(switchExpr.X).(*ast.Ident)
+		}
 
 		if s.Body != nil {
 			for _, clause := range s.Body.List {
@@ -344,8 +341,12 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 						if state, ok := caseStates[switchVar]; ok {
 							for _, caseExpr := range cc.List {
 								typeName := internal.ExtractTypeNameFromExpr(pass, caseExpr)
-								if typeName != "" {
+								if typeName != "" && causeReaches(state, typeName, switchCause) {
 									state.checked[typeName] = true
+									csa.reportFormMismatchedCase(caseExpr, state, typeName)
+									if switchesOnVar {
+										csa.reportWrapUnsafeTypeSwitch(s, caseExpr, switchExpr.X, state, typeName)
+									}
 								}
 							}
 						}
@@ -369,20 +370,18 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 	case *ast.ReturnStmt:
 		// Check if error variables are propagated
 		for varObj, state := range states {
+			// A bare return propagates the named results
+			if len(s.Results) == 0 && csa.namedResults[varObj] && canPropagate {
+				markPropagated(state)
+			}
 			for _, result := range s.Results {
 				if csa.isVariablePropagatedInReturn(result, varObj) {
 					if canPropagate {
-						// Mark errors as "checked" since we're propagating.
-						// If propagatableKeys is set (inside a switch case with errors.Is narrowing),
-						// only propagate the narrowed errors, not all errors.
-						for _, errInfo := range state.errors {
-							key := errInfo.Key()
-							if state.propagatableKeys == nil || state.propagatableKeys[key] {
-								state.checked[key] = true
-							}
-						}
+						markPropagated(state)
 					}
 				} else {
+					// Errors formatted with %v/%s instead of %w are reported at the formatting site
+					csa.reportFlattenedErrors(result, varObj, state)
 					// Not fully propagated - check for partial checks via ParameterCheckedErrorsFact
 					csa.markCheckedErrorsFromCall(result, varObj, state)
 				}
@@ -397,7 +396,7 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 			csa.walkStatementWithScope(s.Init, states, canPropagate)
 		}
 		if s.Cond != nil {
-			collectErrorsIsInExpr(pass, s.Cond, states)
+			csa.collectErrorsIsInExpr(s.Cond, states)
 		}
 		if s.Post != nil {
 			csa.walkStatementWithScope(s.Post, states, canPropagate)
@@ -407,13 +406,32 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 		}
 
 	case *ast.RangeStmt:
+		// for v, err := range repo.All(ctx): err carries the errors the iterator yields,
+		// which must be handled in every iteration
+		errorVar, iterErrs := csa.rangeIteratorErrors(s)
+		if errorVar != nil {
+			if existingState, ok := states[errorVar]; ok {
+				csa.reportUncheckedErrors(existingState)
+			}
+			states[errorVar] = &errorVarState{
+				callPos: s.X.Pos(),
+				errors:  iterErrs,
+				checked: make(map[string]bool),
+			}
+		}
 		if s.Body != nil {
 			csa.walkStatementsWithScope(s.Body.List, states, canPropagate)
 		}
+		if errorVar != nil {
+			if state, ok := states[errorVar]; ok {
+				csa.reportUncheckedErrors(state)
+				delete(states, errorVar)
+			}
+		}
 
 	case *ast.DeferStmt:
 		// Check the deferred call expression for errors.Is/As
-		collectErrorsIsInExpr(pass, s.Call, states)
+		csa.collectErrorsIsInExpr(s.Call, states)
 
 	case *ast.SelectStmt:
 		if s.Body != nil {
@@ -451,7 +469,7 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 						if i < len(valueSpec.Names) {
 							obj := pass.TypesInfo.Defs[valueSpec.Names[i]]
 							if varObj, ok := obj.(*types.Var); ok {
-								if internal.IsErrorType(varObj.Type()) {
+								if isTrackedErrorResult(pass, call, 0, varObj.Type()) {
 									states[varObj] = &errorVarState{
 										callPos: call.Pos(),
 										errors:  fnFact.Errors,
@@ -468,7 +486,8 @@ func (csa *CallSiteAnalyzer) walkStatementWithScope(stmt ast.Stmt, states map[*t
 }
 
 // collectErrorsIsInExpr finds errors.Is/As calls and direct comparisons in an expression and marks errors as checked.
-func collectErrorsIsInExpr(pass *analysis.Pass, expr ast.Expr, states map[*types.Var]*errorVarState) {
+func (csa *CallSiteAnalyzer) collectErrorsIsInExpr(expr ast.Expr, states map[*types.Var]*errorVarState) {
+	pass := csa.Pass
 	ast.Inspect(expr, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.CallExpr:
@@ -477,9 +496,12 @@ func collectErrorsIsInExpr(pass *analysis.Pass, expr ast.Expr, states map[*types
 					// Find which error variable is being checked
 					for varObj, state := range states {
 						if internal.ReferencesVariable(pass, node.Args[0], varObj) {
-							errorKey := internal.ExtractErrorKey(pass, node.Args[1])
-							if errorKey != "" {
-								state.checked[errorKey] = true
+							// Checkers like cockroachdb's errors.IsAny accept several targets
+							for _, target := range node.Args[1:] {
+								errorKey := internal.ExtractErrorKey(pass, target)
+								if errorKey != "" {
+									state.checked[errorKey] = true
+								}
 							}
 						}
 					}
@@ -493,17 +515,34 @@ func collectErrorsIsInExpr(pass *analysis.Pass, expr ast.Expr, states map[*types
 							errorKey := internal.ExtractErrorKeyFromAsTarget(pass, node.Args[1])
 							if errorKey != "" {
 								state.checked[errorKey] = true
+								csa.reportFormMismatchedAsTarget(node.Args[1], state, errorKey)
 							}
 						}
 					}
 				}
 			}
 
+			// Errors passed on to the consumer of an iterator (yield(v, err))
+			csa.markYieldedErrorArgs(node, states)
+
+			// Custom error-checking helpers (e.g., apperr.IsNotFound(err))
+			if checkFact := internal.LookupCheckFunction(pass, node); checkFact != nil && checkFact.ParamIndex < len(node.Args) {
+				for varObj, state := range states {
+					if internal.ReferencesVariable(pass, node.Args[checkFact.ParamIndex], varObj) {
+						for _, errInfo := range checkFact.Errors {
+							state.checked[errInfo.Key()] = true
+						}
+					}
+				}
+			}
+
 		case *ast.BinaryExpr:
 			if node.Op == token.EQL || node.Op == token.NEQ {
 				// Try both directions: err == ErrX or ErrX == err
-				tryMarkDirectComparison(pass, node.X, node.Y, states)
-				tryMarkDirectComparison(pass, node.Y, node.X, states)
+				csa.tryMarkDirectComparison(node, node.X, node.Y, states)
+				csa.tryMarkDirectComparison(node, node.Y, node.X, states)
+				csa.tryMarkCodeComparison(node.X, node.Y, states)
+				csa.tryMarkCodeComparison(node.Y, node.X, states)
 			}
 		}
 
@@ -511,24 +550,84 @@ func collectErrorsIsInExpr(pass *analysis.Pass, expr ast.Expr, states map[*types
 	})
 }
 
+// tryMarkCodeComparison marks the errors covered by comparing a code helper's result
+// with a constant (status.Code(err) == codes.NotFound) as checked.
+func (csa *CallSiteAnalyzer) tryMarkCodeComparison(call, value ast.Expr, states map[*types.Var]*errorVarState) {
+	arg, errs := internal.LookupCodeComparison(csa.Pass, call, value)
+	if arg == nil {
+		return
+	}
+	for varObj, state := range states {
+		if internal.ReferencesVariable(csa.Pass, arg, varObj) {
+			for _, errInfo := range errs {
+				state.checked[errInfo.Key()] = true
+			}
+		}
+	}
+}
+
 // tryMarkDirectComparison checks if lhs is a tracked error variable and rhs is a known error,
-// and marks the error as checked if so.
-func tryMarkDirectComparison(pass *analysis.Pass, lhs, rhs ast.Expr, states map[*types.Var]*errorVarState) {
+// and marks the error as checked if so. A comparison of the variable itself against an
+// error that may arrive wrapped is reported, since it never matches the wrapped error.
+func (csa *CallSiteAnalyzer) tryMarkDirectComparison(cmp *ast.BinaryExpr, lhs, rhs ast.Expr, states map[*types.Var]*errorVarState) {
+	pass := csa.Pass
 	for varObj, state := range states {
 		if internal.ReferencesVariable(pass, lhs, varObj) {
 			errorKey := internal.ExtractErrorKey(pass, rhs)
-			if errorKey != "" {
+			if _, cause := internal.UnwrapCauseExpr(pass, lhs); errorKey != "" && causeReaches(state, errorKey, cause) {
 				state.checked[errorKey] = true
+				if ident, ok := ast.Unparen(lhs).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == varObj {
+					csa.reportWrapUnsafeComparison(cmp, lhs, rhs, state, errorKey)
+				}
 			}
 		}
 	}
 }
 
+// causeReaches reports whether the root-cause function cause, if any, returns the error
+// with the given key when called with the variable of state. pkg/errors.Cause does not
+// return an error that arrives wrapped by fmt.Errorf %w, so comparing its result does
+// not check that error.
+func causeReaches(state *errorVarState, key string, cause internal.WrapperFunc) bool {
+	if !cause.Unwraps {
+		return true
+	}
+	for _, errInfo := range state.errors {
+		if errInfo.Key() == key && !cause.Reaches(errInfo) {
+			return false
+		}
+	}
+	return true
+}
+
+// markPropagated marks the errors of state as checked, since they are propagated to the caller.
+// If propagatableKeys is set (inside a switch case with errors.Is narrowing),
+// only the narrowed errors are propagated, not all errors.
+func markPropagated(state *errorVarState) {
+	for _, errInfo := range state.errors {
+		key := errInfo.Key()
+		if state.propagatableKeys == nil || state.propagatableKeys[key] {
+			state.checked[key] = true
+		}
+	}
+}
+
+// recordNamedResults records the named error results of sig.
+func (csa *CallSiteAnalyzer) recordNamedResults(sig *types.Signature) {
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		if v := results.At(i); v.Name() != "" && v.Name() != "_" && internal.IsErrorResultType(v.Type()) {
+			csa.namedResults[v] = true
+		}
+	}
+}
+
 // isVariablePropagatedInReturn checks if a variable's errors are propagated
 // through a return expression. It distinguishes between:
 // - Direct return (return err) -> propagation
 // - Function call with ParameterFlowFact (return WrapError(err)) -> propagation
 // - fmt.Errorf with %w (return fmt.Errorf("...: %w", err)) -> propagation
+// - Other wrapper functions (return errors.Wrap(err, "...")) -> propagation
 // - Function call without ParameterFlowFact (return ConsumeError(err)) -> NOT propagation
 func (csa *CallSiteAnalyzer) isVariablePropagatedInReturn(result ast.Expr, targetVar *types.Var) bool {
 	pass := csa.Pass
@@ -546,9 +645,9 @@ func (csa *CallSiteAnalyzer) isVariablePropagatedInReturn(result ast.Expr, targe
 			return internal.ReferencesVariable(pass, result, targetVar)
 		}
 
-		// Special case: fmt.Errorf with %w
-		if internal.IsFmtErrorfCall(pass, expr) {
-			return internal.IsFmtErrorfWrappingVariable(pass, expr, targetVar)
+		// Special case: wrapper functions (fmt.Errorf with %w, errors.Wrap, ...)
+		if _, ok := internal.WrappedArgs(pass, expr); ok {
+			return internal.IsWrappingVariable(pass, expr, targetVar)
 		}
 
 		// Check if the called function has ParameterFlowFact for this argument
@@ -642,6 +741,82 @@ func (csa *CallSiteAnalyzer) markTransferredErrorArgs(call *ast.CallExpr, states
 	}
 }
 
+// reportFlattenedErrors reports errors of a tracked variable that are returned through
+// fmt.Errorf (or an equivalent format-based wrapper) with a verb other than %w, e.g.
+// return fmt.Errorf("failed: %v", err). The errors are reported at the formatting site
+// with a suggested fix to use %w, and are then treated as handled so that the call
+// that produced them is not reported again.
+func (csa *CallSiteAnalyzer) reportFlattenedErrors(result ast.Expr, targetVar *types.Var, state *errorVarState) {
+	pass := csa.Pass
+	call, ok := result.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fn := internal.GetCalledFunction(pass, call)
+	if fn == nil {
+		return
+	}
+	wrapper, ok := internal.LookupWrapper(fn)
+	if !ok || wrapper.FormatIndex < 0 || wrapper.FormatIndex >= len(call.Args) {
+		return
+	}
+	formatLit, ok := call.Args[wrapper.FormatIndex].(*ast.BasicLit)
+	if !ok || formatLit.Kind != token.STRING {
+		return
+	}
+
+	for _, verb := range internal.FindFormatVerbs(internal.ExtractStringLiteral(formatLit)) {
+		if verb.Verb == 'w' {
+			continue
+		}
+		argIdx := wrapper.FormatIndex + 1 + verb.ArgIndex
+		if argIdx >= len(call.Args) {
+			continue
+		}
+		ident, ok := call.Args[argIdx].(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[ident] != targetVar {
+			continue
+		}
+
+		// The verb starts after the opening quote of the literal
+		verbPos := formatLit.Pos() + token.Pos(1+verb.Offset)
+		fix := analysis.SuggestedFix{
+			Message: "Use %w to keep the error matchable",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     verbPos,
+				End:     verbPos + token.Pos(len(verb.Text)),
+				NewText: []byte("%w"),
+			}},
+		}
+
+		for _, errInfo := range state.errors {
+			key := errInfo.Key()
+			if !isReportableError(pass, errInfo) || csa.isErrorSatisfied(state, errInfo) {
+				continue
+			}
+			// Inside a narrowed switch case only the narrowed errors can reach this return
+			if state.propagatableKeys != nil && !state.propagatableKeys[key] {
+				continue
+			}
+			state.checked[key] = true
+
+			if csa.reported[call.Pos()][key] {
+				continue
+			}
+			if csa.reported[call.Pos()] == nil {
+				csa.reported[call.Pos()] = make(map[string]bool)
+			}
+			csa.reported[call.Pos()][key] = true
+			pass.Report(analysis.Diagnostic{
+				Pos:            call.Pos(),
+				End:            call.End(),
+				Message:        fmt.Sprintf("%s is flattened by %s and can no longer be matched by callers", key, verb.Text),
+				SuggestedFixes: []analysis.SuggestedFix{fix},
+			})
+		}
+	}
+}
+
 // markCheckedErrorsFromCall checks if a return expression is a function call
 // with ParameterCheckedErrorsFact and marks the checked errors on the variable's state.
 func (csa *CallSiteAnalyzer) markCheckedErrorsFromCall(result ast.Expr, targetVar *types.Var, state *errorVarState) {
@@ -686,34 +861,83 @@ func markPartialCheckedErrors(state *errorVarState, checkedFact *facts.Parameter
 }
 
 // reportUncheckedErrors reports any errors that haven't been checked.
-// The reported map tracks (callPos, errorKey) pairs already reported to prevent
-// duplicate diagnostics when deferred re-analysis re-walks the same function body.
-func reportUncheckedErrors(pass *analysis.Pass, state *errorVarState, reported map[token.Pos]map[string]bool) {
+// csa.reported tracks (callPos, errorKey) pairs already reported to prevent
+// duplicate diagnostics when the same call site is reached more than once.
+func (csa *CallSiteAnalyzer) reportUncheckedErrors(state *errorVarState) {
+	pass := csa.Pass
 	for _, errInfo := range state.errors {
-		// Skip ignored packages
-		if internal.ShouldIgnorePackage(errInfo.PkgPath) {
+		if !isReportableError(pass, errInfo) {
 			continue
 		}
-		// Skip unexported errors from other packages.
-		// These cannot be checked with errors.Is/errors.As from outside the package.
-		if errInfo.PkgPath != pass.Pkg.Path() && !token.IsExported(errInfo.Name) {
-			continue
+		if !csa.isErrorSatisfied(state, errInfo) {
+			// Skip if already reported
+			if csa.markReported(state.callPos, errInfo.Key()) {
+				continue
+			}
+			pass.Reportf(state.callPos, "missing errors.Is check for %s", csa.sentinels.VisibleKey(errInfo))
 		}
-		key := errInfo.Key()
-		if !state.checked[key] {
-			// Skip if already reported (prevents duplicates across first-pass and deferred re-analysis)
-			if reported != nil {
-				if reported[state.callPos][key] {
-					continue
-				}
-				if reported[state.callPos] == nil {
-					reported[state.callPos] = make(map[string]bool)
-				}
-				reported[state.callPos][key] = true
+	}
+}
+
+// isReportableError checks if a caller is expected to check the error.
+func isReportableError(pass *analysis.Pass, errInfo facts.ErrorInfo) bool {
+	// Skip ignored packages
+	if internal.ShouldIgnorePackage(errInfo.PkgPath) {
+		return false
+	}
+	// Skip unexported errors from other packages.
+	// These cannot be checked with errors.Is/errors.As from outside the package.
+	return errInfo.PkgPath == pass.Pkg.Path() || token.IsExported(errInfo.Name)
+}
+
+// maxWrapperDepth limits how many nested wrapper types are followed.
+const maxWrapperDepth = 10
+
+// isErrorSatisfied checks if an error is covered by the checks recorded in state.
+// Errors reached through a wrapper type are also covered by a check of the wrapper
+// (e.g., errors.As(err, &opErr) covers ErrTimeout in &OpError{Err: ErrTimeout}),
+// and a wrapper is covered once every error it carries has been checked.
+// A hierarchical sentinel is also covered by a check of any sentinel it wraps.
+func (csa *CallSiteAnalyzer) isErrorSatisfied(state *errorVarState, errInfo facts.ErrorInfo) bool {
+	if state.checked[errInfo.Key()] {
+		return true
+	}
+
+	// errors.Is(err, ErrNotFound) matches var ErrUserNotFound = fmt.Errorf("user: %w", ErrNotFound)
+	for _, ancestor := range csa.sentinels.Ancestors(errInfo) {
+		if state.checked[ancestor] {
+			return true
+		}
+	}
+
+	// Walk up the chain of wrappers
+	wrapper := errInfo.WrappedBy
+	for depth := 0; wrapper != "" && depth < maxWrapperDepth; depth++ {
+		if state.checked[wrapper] {
+			return true
+		}
+		next := ""
+		for _, other := range state.errors {
+			if other.Key() == wrapper {
+				next = other.WrappedBy
+				break
 			}
-			pass.Reportf(state.callPos, "missing errors.Is check for %s", key)
 		}
+		wrapper = next
 	}
+
+	// A wrapper is covered when all errors it carries are checked
+	hasInner := false
+	for _, other := range state.errors {
+		if other.WrappedBy != errInfo.Key() {
+			continue
+		}
+		if !state.checked[other.Key()] {
+			return false
+		}
+		hasInner = true
+	}
+	return hasInner
 }
 
 // cloneStates creates a deep copy of the states map.
@@ -784,10 +1008,47 @@ func mergeStates(states map[*types.Var]*errorVarState, ifStates, elseStates map[
 }
 
 // getCallErrors returns the FunctionErrorsFact and signature for a call expression.
+// In whole-program mode, interface method calls and calls of function values also
+// return the errors of the callees found in the program's call graph.
+func (csa *CallSiteAnalyzer) getCallErrors(call *ast.CallExpr) (*facts.FunctionErrorsFact, *types.Signature) {
+	fact, sig := csa.getFactCallErrors(call)
+
+	dynamicErrs := csa.CallSites.Lookup(csa.Pass.Fset.Position(call.Lparen))
+	if len(dynamicErrs) == 0 {
+		return fact, sig
+	}
+	if fact == nil {
+		t := csa.Pass.TypesInfo.TypeOf(call.Fun)
+		if t == nil {
+			return nil, nil
+		}
+		var ok bool
+		if sig, ok = t.Underlying().(*types.Signature); !ok {
+			return nil, nil
+		}
+		fact = &facts.FunctionErrorsFact{}
+	}
+	for _, errInfo := range dynamicErrs {
+		fact.AddError(errInfo)
+	}
+	return fact, sig
+}
+
+// getFactCallErrors returns the FunctionErrorsFact and signature for a call expression
+// as far as facts of the current package and its dependencies tell.
 // It handles both regular function calls and closure variable calls.
 // It also resolves errors through ParameterFlowFact.
-func (csa *CallSiteAnalyzer) getCallErrors(call *ast.CallExpr) (*facts.FunctionErrorsFact, *types.Signature) {
+func (csa *CallSiteAnalyzer) getFactCallErrors(call *ast.CallExpr) (*facts.FunctionErrorsFact, *types.Signature) {
 	pass := csa.Pass
+
+	// Interface method call on a receiver of known concrete types
+	if fact, sig, ok := csa.getDevirtualizedCallErrors(call); ok {
+		if len(fact.Errors) > 0 {
+			return fact, sig
+		}
+		return nil, nil
+	}
+
 	// First, try to get it as a regular function
 	calledFn := internal.GetCalledFunction(pass, call)
 	if calledFn != nil {
@@ -844,8 +1105,8 @@ func (csa *CallSiteAnalyzer) getCallErrors(call *ast.CallExpr) (*facts.FunctionE
 			}
 		}
 
-		// Merge InterfaceMethodFact from global store (handles DI pattern where
-		// both call flow and direct errors come from the global store)
+		// Merge errors of the interface's implementations (handles the DI pattern,
+		// where implementations are known through ImplementsFact or the index)
 		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
 			if ifaceFact, _ := csa.getInterfaceMethodErrors(sel); ifaceFact != nil && len(ifaceFact.Errors) > 0 {
 				result.Merge(ifaceFact)
@@ -873,23 +1134,21 @@ func (csa *CallSiteAnalyzer) getCallErrors(call *ast.CallExpr) (*facts.FunctionE
 		}
 	}
 
-	// Try to get it as a closure variable
-	ident, ok := call.Fun.(*ast.Ident)
-	if !ok {
-		return nil, nil
-	}
-
-	obj := pass.TypesInfo.Uses[ident]
-	varObj, ok := obj.(*types.Var)
-	if !ok {
+	// Try to get it as a closure variable or a function-typed field (s.onSave(), r.handlers[path]())
+	varObj := internal.FuncValueVar(pass, call.Fun)
+	if varObj == nil {
 		return nil, nil
 	}
 
 	// Check if the variable has a FunctionErrorsFact
 	var fnFact facts.FunctionErrorsFact
 	if pass.ImportObjectFact(varObj, &fnFact) {
-		// Get the signature from the variable's type
-		sig, ok := varObj.Type().Underlying().(*types.Signature)
+		// Get the signature from the called expression's type
+		t := pass.TypesInfo.TypeOf(call.Fun)
+		if t == nil {
+			return nil, nil
+		}
+		sig, ok := t.Underlying().(*types.Signature)
 		if !ok {
 			return nil, nil
 		}
@@ -899,8 +1158,85 @@ func (csa *CallSiteAnalyzer) getCallErrors(call *ast.CallExpr) (*facts.FunctionE
 	return nil, nil
 }
 
+// getDevirtualizedCallErrors returns the errors of an interface method call whose receiver
+// can only hold specific concrete types, such as var r Repository = &UserRepo{}.
+// Only the methods of those types are used instead of all implementations of the interface.
+// The last result is false if the call is not an interface method call, its receiver
+// types are unknown, a receiver type only forwards the method to an embedded interface,
+// or a concrete method has no facts, in which case the interface union is used instead.
+func (csa *CallSiteAnalyzer) getDevirtualizedCallErrors(call *ast.CallExpr) (*facts.FunctionErrorsFact, *types.Signature, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	ifaceType, method := csa.resolveInterfaceMethod(sel)
+	if ifaceType == nil {
+		return nil, nil, false
+	}
+	concreteTypes := csa.receiverTypes(call)
+	if concreteTypes == nil {
+		return nil, nil, false
+	}
+
+	pass := csa.Pass
+	result := &facts.FunctionErrorsFact{}
+	for _, concreteType := range concreteTypes {
+		concreteMethod := internal.FindMethodImplementation(concreteType, method)
+		if concreteMethod == nil || internal.IsAbstractMethod(concreteMethod) {
+			return nil, nil, false
+		}
+		// Facts are attached to the generic method, not to (*GenRepo[int]).Get
+		concreteMethod = concreteMethod.Origin()
+
+		hasFact := false
+		var fnFact facts.FunctionErrorsFact
+		if pass.ImportObjectFact(concreteMethod, &fnFact) {
+			result.Merge(&fnFact)
+			hasFact = true
+		}
+		var flowFact facts.ParameterFlowFact
+		if pass.ImportObjectFact(concreteMethod, &flowFact) {
+			for _, err := range resolveParameterFlowErrorsAST(pass, call, &flowFact) {
+				result.AddError(err)
+			}
+			hasFact = true
+		}
+		var callFlowFact facts.FunctionParamCallFlowFact
+		if pass.ImportObjectFact(concreteMethod, &callFlowFact) {
+			for _, err := range resolveFunctionParamCallFlowErrors(pass, call, &callFlowFact) {
+				result.AddError(err)
+			}
+			hasFact = true
+		}
+		if !hasFact {
+			return nil, nil, false
+		}
+	}
+	return result, method.Type().(*types.Signature), true
+}
+
+// receiverTypes returns the concrete types the receiver of an interface method call can hold,
+// or nil if they are unknown.
+func (csa *CallSiteAnalyzer) receiverTypes(call *ast.CallExpr) []*types.Named {
+	if csa.invokeSites == nil {
+		csa.invokeSites = make(map[token.Pos]*ssa.CallCommon)
+		if ssaResult, ok := csa.Pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA); ok {
+			csa.invokeSites = ssaanalysis.InvokeSites(ssaResult)
+		}
+	}
+	site, ok := csa.invokeSites[call.Lparen]
+	if !ok {
+		return nil
+	}
+	return ssaanalysis.ReceiverTypes(site)
+}
+
 // getInterfaceMethodErrors returns errors from an interface method call.
-// It checks if the receiver is an interface type and returns the InterfaceMethodFact.
+// If the method declares its errors with //goexhauerrors:returns, the contract is the result.
+// Otherwise the result is the union of the InterfaceMethodFact exported by the interface's package,
+// the implementations visible from this package, and the implementation index.
+// Every source depends only on the import graph and the index, never on the order
+// in which packages are analyzed.
 func (csa *CallSiteAnalyzer) getInterfaceMethodErrors(sel *ast.SelectorExpr) (*facts.FunctionErrorsFact, *types.Signature) {
 	ifaceType, method := csa.resolveInterfaceMethod(sel)
 	if ifaceType == nil {
@@ -914,33 +1250,31 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodErrors(sel *ast.SelectorExpr) (*f
 		return nil, nil
 	}
 
-	// Check for InterfaceMethodFact
-	var ifaceFact facts.InterfaceMethodFact
-	if pass.ImportObjectFact(method, &ifaceFact) {
-		return &facts.FunctionErrorsFact{Errors: ifaceFact.Errors}, sig
-	}
+	result := &facts.FunctionErrorsFact{}
 
-	// Fallback: check the global store for cross-package DI pattern.
-	// This handles the case where the implementation package is not in the caller's import graph.
-	if ifaceTypeName := findInterfaceTypeName(pass, ifaceType); ifaceTypeName != nil {
-		key := facts.InterfaceMethodKey(ifaceTypeName.Pkg().Path(), ifaceTypeName.Name(), method.Name())
-		if globalFact, ok := facts.LoadInterfaceMethodFact(key); ok {
-			return &facts.FunctionErrorsFact{Errors: globalFact.Errors}, sig
+	// A declared contract replaces the implementations
+	var contract facts.ErrorContractFact
+	if pass.ImportObjectFact(method, &contract) {
+		if len(contract.Errors) == 0 {
+			return nil, nil
 		}
-		// Mark that we had a global store miss so the caller can register for deferred re-analysis
-		csa.hadGlobalStoreMiss = true
+		result.Errors = append(result.Errors, contract.Errors...)
+		return result, sig
 	}
 
-	// If no fact found, try to find implementations in the current package
-	// This handles the case where the interface and implementations are in the same package
-	implementingTypes := csa.InterfaceImpls.GetImplementingTypes(ifaceType)
+	// Implementations known to the interface's package
+	var ifaceFact facts.InterfaceMethodFact
+	if pass.ImportObjectFact(method, &ifaceFact) {
+		result.Errors = append(result.Errors, ifaceFact.Errors...)
+	}
 
-	result := &facts.FunctionErrorsFact{}
-	for _, concreteType := range implementingTypes {
+	// Implementations in this package and the packages it imports
+	for _, concreteType := range csa.implementingTypes(ifaceType) {
 		concreteMethod := internal.FindMethodImplementation(concreteType, method)
 		if concreteMethod == nil {
 			continue
 		}
+		concreteMethod = concreteMethod.Origin()
 
 		var fnFact facts.FunctionErrorsFact
 		if pass.ImportObjectFact(concreteMethod, &fnFact) {
@@ -948,6 +1282,13 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodErrors(sel *ast.SelectorExpr) (*f
 		}
 	}
 
+	// Implementations in packages this package does not import (DI pattern)
+	for _, entry := range csa.implIndexEntries(ifaceType, method) {
+		for _, errInfo := range entry.Errors {
+			result.AddError(errInfo)
+		}
+	}
+
 	if len(result.Errors) > 0 {
 		return result, sig
 	}
@@ -955,15 +1296,96 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodErrors(sel *ast.SelectorExpr) (*f
 	return nil, nil
 }
 
+// implementingTypes returns the types implementing ifaceType that are visible from this package:
+// those in the current and directly imported packages, followed by those of transitively
+// imported packages that declared the implementation with an ImplementsFact.
+func (csa *CallSiteAnalyzer) implementingTypes(ifaceType *types.Interface) []*types.Named {
+	local := csa.InterfaceImpls.GetImplementingTypes(ifaceType)
+
+	ifaceTypeName := findInterfaceTypeName(csa.Pass, csa.InterfaceImpls.Imports(), ifaceType)
+	if ifaceTypeName == nil || ifaceTypeName.Pkg() == nil {
+		return local
+	}
+	if csa.implementers == nil {
+		csa.collectImplementers()
+	}
+	imported := csa.implementers[ifaceTypeName.Pkg().Path()+"."+ifaceTypeName.Name()]
+	if len(imported) == 0 {
+		return local
+	}
+
+	result := append([]*types.Named(nil), local...)
+	seen := make(map[*types.Named]bool)
+	for _, named := range local {
+		seen[named] = true
+	}
+	for _, named := range imported {
+		if !seen[named] {
+			seen[named] = true
+			result = append(result, named)
+		}
+	}
+	return result
+}
+
+// collectImplementers indexes the ImplementsFacts of all packages visible from this one
+// by interface key. Types are ordered by package path and name so that the result is
+// independent of the order facts were imported in.
+func (csa *CallSiteAnalyzer) collectImplementers() {
+	csa.implementers = make(map[string][]*types.Named)
+
+	var typeNames []*types.TypeName
+	implemented := make(map[*types.TypeName]*facts.ImplementsFact)
+	for _, objFact := range csa.Pass.AllObjectFacts() {
+		fact, ok := objFact.Fact.(*facts.ImplementsFact)
+		if !ok {
+			continue
+		}
+		tn, ok := objFact.Object.(*types.TypeName)
+		if !ok || tn.Pkg() == nil {
+			continue
+		}
+		typeNames = append(typeNames, tn)
+		implemented[tn] = fact
+	}
+	sort.Slice(typeNames, func(i, j int) bool {
+		if typeNames[i].Pkg().Path() != typeNames[j].Pkg().Path() {
+			return typeNames[i].Pkg().Path() < typeNames[j].Pkg().Path()
+		}
+		return typeNames[i].Name() < typeNames[j].Name()
+	})
+
+	for _, tn := range typeNames {
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		for _, key := range implemented[tn].Interfaces {
+			csa.implementers[key] = append(csa.implementers[key], named)
+		}
+	}
+}
+
+// implIndexEntries returns the implementation index entries of an interface method.
+// Entries are keyed by the interface declaring the method, so a method inherited by
+// embedding is found under the embedded interface.
+func (csa *CallSiteAnalyzer) implIndexEntries(ifaceType *types.Interface, method *types.Func) []*implindex.Entry {
+	key := internal.InterfaceMethodKey(findInterfaceTypeName(csa.Pass, csa.InterfaceImpls.Imports(), ifaceType), method)
+	if key == "" {
+		return nil
+	}
+	return implindex.Lookup(key)
+}
+
 // findInterfaceTypeName finds the *types.TypeName for a given interface type
-// by searching the package scope and imported package scopes.
-func findInterfaceTypeName(pass *analysis.Pass, ifaceType *types.Interface) *types.TypeName {
+// by searching the package scope and the scopes of the transitively imported packages in imports.
+func findInterfaceTypeName(pass *analysis.Pass, imports []*types.Package, ifaceType *types.Interface) *types.TypeName {
 	// Search current package scope
 	if tn := findInterfaceTypeNameInScope(pass.Pkg.Scope(), ifaceType); tn != nil {
 		return tn
 	}
-	// Search imported package scopes
-	for _, imp := range pass.Pkg.Imports() {
+	// Search imported package scopes, including indirect imports
+	for _, imp := range imports {
 		if tn := findInterfaceTypeNameInScope(imp.Scope(), ifaceType); tn != nil {
 			return tn
 		}
@@ -971,6 +1393,30 @@ func findInterfaceTypeName(pass *analysis.Pass, ifaceType *types.Interface) *typ
 	return nil
 }
 
+// embeddedInterface returns the interface embedded in a struct that a promoted method
+// selection goes through (s.Get with type Service struct{ Repository }), or nil.
+func embeddedInterface(selection *types.Selection) *types.Interface {
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil
+	}
+	t := selection.Recv()
+	index := selection.Index()
+	for _, i := range index[:len(index)-1] {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return nil
+		}
+		t = st.Field(i).Type()
+		if iface, ok := t.Underlying().(*types.Interface); ok {
+			return iface
+		}
+	}
+	return nil
+}
+
 func findInterfaceTypeNameInScope(scope *types.Scope, ifaceType *types.Interface) *types.TypeName {
 	for _, name := range scope.Names() {
 		obj := scope.Lookup(name)
@@ -988,6 +1434,9 @@ func findInterfaceTypeNameInScope(scope *types.Scope, ifaceType *types.Interface
 }
 
 // resolveInterfaceMethod resolves the interface type and method from a selector expression.
+// A receiver of type parameter type resolves to the method of its constraint, and a method
+// promoted from an interface embedded in a struct (type Service struct{ Repository })
+// resolves to the embedded interface.
 // Returns nil for both if the receiver is not an interface type.
 func (csa *CallSiteAnalyzer) resolveInterfaceMethod(sel *ast.SelectorExpr) (*types.Interface, *types.Func) {
 	tv := csa.Pass.TypesInfo.Types[sel.X]
@@ -995,8 +1444,17 @@ func (csa *CallSiteAnalyzer) resolveInterfaceMethod(sel *ast.SelectorExpr) (*typ
 		return nil, nil
 	}
 
-	ifaceType, ok := tv.Type.Underlying().(*types.Interface)
-	if !ok {
+	var ifaceType *types.Interface
+	switch t := tv.Type.(type) {
+	case *types.TypeParam:
+		ifaceType = internal.GetInterfaceType(t)
+	default:
+		ifaceType, _ = t.Underlying().(*types.Interface)
+	}
+	if ifaceType == nil {
+		ifaceType = embeddedInterface(csa.Pass.TypesInfo.Selections[sel])
+	}
+	if ifaceType == nil {
 		return nil, nil
 	}
 
@@ -1025,7 +1483,7 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodParameterFlow(sel *ast.SelectorEx
 	}
 
 	// Dynamically compute intersection from implementations
-	implementingTypes := csa.InterfaceImpls.GetImplementingTypes(ifaceType)
+	implementingTypes := csa.implementingTypes(ifaceType)
 	if len(implementingTypes) == 0 {
 		return nil
 	}
@@ -1036,6 +1494,7 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodParameterFlow(sel *ast.SelectorEx
 		if concreteMethod == nil {
 			continue
 		}
+		concreteMethod = concreteMethod.Origin()
 		var pf facts.ParameterFlowFact
 		if csa.Pass.ImportObjectFact(concreteMethod, &pf) {
 			allFlowFacts = append(allFlowFacts, &pf)
@@ -1063,7 +1522,7 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodCheckedErrors(sel *ast.SelectorEx
 	}
 
 	// Dynamically compute intersection from implementations
-	implementingTypes := csa.InterfaceImpls.GetImplementingTypes(ifaceType)
+	implementingTypes := csa.implementingTypes(ifaceType)
 	if len(implementingTypes) == 0 {
 		return nil
 	}
@@ -1074,6 +1533,7 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodCheckedErrors(sel *ast.SelectorEx
 		if concreteMethod == nil {
 			continue
 		}
+		concreteMethod = concreteMethod.Origin()
 		var cf facts.ParameterCheckedErrorsFact
 		if csa.Pass.ImportObjectFact(concreteMethod, &cf) {
 			allCheckedFacts = append(allCheckedFacts, &cf)
@@ -1112,33 +1572,14 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodCallFlow(sel *ast.SelectorExpr) *
 		return &callFlowFact
 	}
 
-	// Check global store for cross-package DI pattern
-	if ifaceTypeName := findInterfaceTypeName(pass, ifaceType); ifaceTypeName != nil {
-		key := facts.InterfaceMethodKey(ifaceTypeName.Pkg().Path(), ifaceTypeName.Name(), method.Name())
-		if globalFact, ok := facts.LoadCallFlowFact(key); ok {
-			// Key exists: return call flows if non-empty, otherwise nil
-			// (empty means intersection eliminated all flows — not a miss)
-			if len(globalFact.CallFlows) > 0 {
-				return globalFact
-			}
-			return nil
-		}
-		// Key absent: mark global store miss for deferred re-analysis
-		csa.hadGlobalStoreMiss = true
-	}
-
 	// Dynamically compute intersection from implementations
-	implementingTypes := csa.InterfaceImpls.GetImplementingTypes(ifaceType)
-	if len(implementingTypes) == 0 {
-		return nil
-	}
-
 	var allCallFlowFacts []*facts.FunctionParamCallFlowFact
-	for _, concreteType := range implementingTypes {
+	for _, concreteType := range csa.implementingTypes(ifaceType) {
 		concreteMethod := internal.FindMethodImplementation(concreteType, method)
 		if concreteMethod == nil {
 			continue
 		}
+		concreteMethod = concreteMethod.Origin()
 		var cf facts.FunctionParamCallFlowFact
 		if pass.ImportObjectFact(concreteMethod, &cf) {
 			allCallFlowFacts = append(allCallFlowFacts, &cf)
@@ -1147,6 +1588,11 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodCallFlow(sel *ast.SelectorExpr) *
 		}
 	}
 
+	// Each index entry is already the intersection within its implementation package
+	for _, entry := range csa.implIndexEntries(ifaceType, method) {
+		allCallFlowFacts = append(allCallFlowFacts, &facts.FunctionParamCallFlowFact{CallFlows: entry.CallFlows})
+	}
+
 	return facts.IntersectFunctionParamCallFlowFacts(allCallFlowFacts)
 }
 
@@ -1155,43 +1601,97 @@ func (csa *CallSiteAnalyzer) getInterfaceMethodCallFlow(sel *ast.SelectorExpr) *
 type flowInfo interface {
 	Index() int
 	IsWrapped() bool
+	HidesCause() bool
+	WrapperKey() string
+	EachElement() bool
+	MethodName() string
 }
 
 // paramFlowAdapter adapts facts.ParameterFlowInfo to flowInfo interface.
 type paramFlowAdapter struct{ f facts.ParameterFlowInfo }
 
-func (a paramFlowAdapter) Index() int      { return a.f.ParamIndex }
-func (a paramFlowAdapter) IsWrapped() bool { return a.f.Wrapped }
+func (a paramFlowAdapter) Index() int         { return a.f.ParamIndex }
+func (a paramFlowAdapter) IsWrapped() bool    { return a.f.Wrapped }
+func (a paramFlowAdapter) HidesCause() bool   { return a.f.CauseHidden }
+func (a paramFlowAdapter) WrapperKey() string { return a.f.WrappedBy }
+func (a paramFlowAdapter) EachElement() bool  { return false }
+func (a paramFlowAdapter) MethodName() string { return "" }
 
 // funcParamCallFlowAdapter adapts facts.FunctionParamCallFlowInfo to flowInfo interface.
 type funcParamCallFlowAdapter struct{ f facts.FunctionParamCallFlowInfo }
 
-func (a funcParamCallFlowAdapter) Index() int      { return a.f.ParamIndex }
-func (a funcParamCallFlowAdapter) IsWrapped() bool { return a.f.Wrapped }
+func (a funcParamCallFlowAdapter) Index() int         { return a.f.ParamIndex }
+func (a funcParamCallFlowAdapter) IsWrapped() bool    { return a.f.Wrapped }
+func (a funcParamCallFlowAdapter) HidesCause() bool   { return a.f.CauseHidden }
+func (a funcParamCallFlowAdapter) WrapperKey() string { return "" }
+func (a funcParamCallFlowAdapter) EachElement() bool  { return a.f.Elements }
+func (a funcParamCallFlowAdapter) MethodName() string { return a.f.Method }
 
 // resolveFlowErrors resolves errors from call arguments based on flow information.
 func resolveFlowErrors(pass *analysis.Pass, call *ast.CallExpr, flows []flowInfo) []facts.ErrorInfo {
 	var errs []facts.ErrorInfo
 
 	for _, flow := range flows {
-		if flow.Index() < 0 || flow.Index() >= len(call.Args) {
-			continue
-		}
-
-		arg := call.Args[flow.Index()]
-		argErrors := extractErrorsFromExpr(pass, arg)
+		for _, arg := range flowArgs(pass, call, flow) {
+			var argErrors []facts.ErrorInfo
+			if name := flow.MethodName(); name != "" {
+				argErrors = methodFlowErrors(pass, pass.TypesInfo.TypeOf(arg), name)
+			} else {
+				argErrors = extractErrorsFromExpr(pass, arg)
+			}
 
-		for _, err := range argErrors {
-			if flow.IsWrapped() {
-				err.Wrapped = true
+			for _, err := range argErrors {
+				if flow.IsWrapped() {
+					err.Wrapped = true
+				}
+				if flow.HidesCause() {
+					err.CauseHidden = true
+				}
+				if err.WrappedBy == "" {
+					err.WrappedBy = flow.WrapperKey()
+				}
+				errs = append(errs, err)
 			}
-			errs = append(errs, err)
 		}
 	}
 
 	return errs
 }
 
+// flowArgs returns the argument expressions a flow refers to. A flow over the elements
+// of a variadic or slice parameter refers to each element: the arguments from the flow's
+// index on (RunAll(f, g)), or the elements of a slice literal (RunAll([]func() error{f, g}...)).
+// Any other slice (RunAll(fns...)) is resolved through the FunctionErrorsFact of the
+// variable or field holding it, which is the union over its elements.
+func flowArgs(pass *analysis.Pass, call *ast.CallExpr, flow flowInfo) []ast.Expr {
+	idx := flow.Index()
+	if idx < 0 || idx >= len(call.Args) {
+		return nil
+	}
+	if !flow.EachElement() {
+		return call.Args[idx : idx+1]
+	}
+
+	arg := call.Args[idx]
+	if t := pass.TypesInfo.TypeOf(arg); t != nil {
+		if _, isSlice := t.Underlying().(*types.Slice); isSlice {
+			lit, ok := ast.Unparen(arg).(*ast.CompositeLit)
+			if !ok {
+				return call.Args[idx : idx+1]
+			}
+			var elems []ast.Expr
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+				elems = append(elems, elt)
+			}
+			return elems
+		}
+	}
+	return call.Args[idx:]
+}
+
 // resolveParameterFlowErrorsAST resolves errors from call arguments based on ParameterFlowFact.
 func resolveParameterFlowErrorsAST(pass *analysis.Pass, call *ast.CallExpr, flowFact *facts.ParameterFlowFact) []facts.ErrorInfo {
 	flows := make([]flowInfo, len(flowFact.Flows))
@@ -1249,6 +1749,11 @@ func extractErrorsFromExpr(pass *analysis.Pass, expr ast.Expr) []facts.ErrorInfo
 					Wrapped: false,
 				})
 			}
+			// Also check for FunctionErrorsFact (for function-typed fields)
+			var fnFact facts.FunctionErrorsFact
+			if pass.ImportObjectFact(varObj, &fnFact) {
+				errs = append(errs, fnFact.Errors...)
+			}
 		}
 		// Also check for named functions (e.g., passing pkg.NamedFunc to a higher-order function)
 		if funcObj, ok := obj.(*types.Func); ok {
@@ -1261,7 +1766,17 @@ func extractErrorsFromExpr(pass *analysis.Pass, expr ast.Expr) []facts.ErrorInfo
 	case *ast.CallExpr:
 		// If the argument is a function call, recursively get its errors
 		calledFn := internal.GetCalledFunction(pass, e)
-		if calledFn != nil {
+		var returnsFunc bool
+		if t := pass.TypesInfo.TypeOf(e); t != nil {
+			_, returnsFunc = t.Underlying().(*types.Signature)
+		}
+		if returnsFunc && calledFn != nil {
+			// The call returns a function value, such as the option of New(WithPort(80))
+			var resultFact facts.FuncResultErrorsFact
+			if pass.ImportObjectFact(calledFn, &resultFact) {
+				errs = append(errs, resultFact.Errors...)
+			}
+		} else if calledFn != nil {
 			var fnFact facts.FunctionErrorsFact
 			if pass.ImportObjectFact(calledFn, &fnFact) {
 				errs = append(errs, fnFact.Errors...)
@@ -1277,12 +1792,12 @@ func extractErrorsFromExpr(pass *analysis.Pass, expr ast.Expr) []facts.ErrorInfo
 	case *ast.UnaryExpr:
 		if e.Op.String() == "&" {
 			if compLit, ok := e.X.(*ast.CompositeLit); ok {
-				errs = append(errs, extractErrorsFromCompositeLit(pass, compLit)...)
+				errs = append(errs, extractErrorsFromCompositeLit(pass, compLit, facts.FormPointer)...)
 			}
 		}
 
 	case *ast.CompositeLit:
-		errs = append(errs, extractErrorsFromCompositeLit(pass, e)...)
+		errs = append(errs, extractErrorsFromCompositeLit(pass, e, facts.FormValue)...)
 
 	case *ast.FuncLit:
 		// Analyze lambda body directly to extract errors
@@ -1292,8 +1807,9 @@ func extractErrorsFromExpr(pass *analysis.Pass, expr ast.Expr) []facts.ErrorInfo
 	return errs
 }
 
-// extractErrorsFromCompositeLit extracts error type information from a composite literal.
-func extractErrorsFromCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLit) []facts.ErrorInfo {
+// extractErrorsFromCompositeLit extracts error type information from a composite literal
+// passed in the given form.
+func extractErrorsFromCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLit, form facts.ErrorForm) []facts.ErrorInfo {
 	var errs []facts.ErrorInfo
 
 	tv := pass.TypesInfo.Types[compLit]
@@ -1317,6 +1833,7 @@ func extractErrorsFromCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLi
 			PkgPath: errorFact.PkgPath,
 			Name:    errorFact.Name,
 			Wrapped: false,
+			Forms:   form,
 		})
 	} else if typeName.Pkg() != nil {
 		// Local custom error type
@@ -1324,6 +1841,7 @@ func extractErrorsFromCompositeLit(pass *analysis.Pass, compLit *ast.CompositeLi
 			PkgPath: typeName.Pkg().Path(),
 			Name:    typeName.Name(),
 			Wrapped: false,
+			Forms:   form,
 		})
 	}
 
@@ -1375,15 +1893,16 @@ func resolveFunctionParamCallFlowErrors(pass *analysis.Pass, call *ast.CallExpr,
 	return resolveFlowErrors(pass, call, flows)
 }
 
-// findErrorVarInAssignmentWithSig finds the error variable in an assignment statement using a signature.
-func findErrorVarInAssignmentWithSig(pass *analysis.Pass, stmt *ast.AssignStmt, rhsIndex int, sig *types.Signature) *types.Var {
+// findErrorVarInAssignmentWithSig finds the error variable receiving the results of call,
+// the right-hand side rhsIndex of an assignment statement, using its signature.
+func findErrorVarInAssignmentWithSig(pass *analysis.Pass, stmt *ast.AssignStmt, rhsIndex int, call *ast.CallExpr, sig *types.Signature) *types.Var {
 	results := sig.Results()
 
 	// Handle multiple return values
 	if len(stmt.Rhs) == 1 && results.Len() > 1 {
 		// Single call with multiple returns: x, err := someFunc()
 		for i := 0; i < results.Len(); i++ {
-			if internal.IsErrorType(results.At(i).Type()) {
+			if isTrackedErrorResult(pass, call, i, results.At(i).Type()) {
 				if i < len(stmt.Lhs) {
 					ident, ok := stmt.Lhs[i].(*ast.Ident)
 					if !ok || ident.Name == "_" {
@@ -1411,7 +1930,7 @@ func findErrorVarInAssignmentWithSig(pass *analysis.Pass, stmt *ast.AssignStmt,
 				obj = pass.TypesInfo.Uses[ident]
 			}
 			if varObj, ok := obj.(*types.Var); ok {
-				if internal.IsErrorType(varObj.Type()) {
+				if isTrackedErrorResult(pass, call, 0, varObj.Type()) {
 					return varObj
 				}
 			}
@@ -1420,3 +1939,23 @@ func findErrorVarInAssignmentWithSig(pass *analysis.Pass, stmt *ast.AssignStmt,
 
 	return nil
 }
+
+// isTrackedErrorResult reports whether a variable of type t receiving result index of
+// call is tracked for unchecked errors. A custom error pointer (*MyError) is only tracked
+// when the callee may return it as nil (NilableResultsFact): a function that never does,
+// such as func NewMyError() *MyError, constructs the error rather than failing with it.
+// Calls of function values and interface methods have no body to tell, so they are tracked.
+func isTrackedErrorResult(pass *analysis.Pass, call *ast.CallExpr, index int, t types.Type) bool {
+	if !internal.IsErrorResultType(t) {
+		return false
+	}
+	if types.IsInterface(t) {
+		return true
+	}
+	fn := internal.GetCalledFunction(pass, call)
+	if fn == nil || internal.IsAbstractMethod(fn) {
+		return true
+	}
+	var nilable facts.NilableResultsFact
+	return pass.ImportObjectFact(fn.Origin(), &nilable) && nilable.Nilable(index)
+}
@@ -30,6 +30,15 @@ var testAnalyzer = &analysis.Analyzer{
 		(*facts.InterfaceMethodFact)(nil),
 		(*facts.FunctionParamCallFlowFact)(nil),
 		(*facts.ParameterCheckedErrorsFact)(nil),
+		(*facts.CheckFunctionFact)(nil),
+		(*facts.UnwrapFact)(nil),
+		(*facts.ImplementsFact)(nil),
+		(*facts.MockFact)(nil),
+		(*facts.ErrorContractFact)(nil),
+		(*facts.ErrorParentFact)(nil),
+		(*facts.IteratorErrorsFact)(nil),
+		(*facts.NilableResultsFact)(nil),
+		(*facts.FuncResultErrorsFact)(nil),
 	},
 }
 
@@ -37,8 +46,17 @@ func runTestAnalyzer(pass *analysis.Pass) (interface{}, error) {
 	// Phase 1: Detect local errors
 	localErrors := detector.DetectLocalErrors(pass)
 
+	// Phase 1b: Detect error-checking helpers
+	analyzer.AnalyzeCheckFunctions(pass, localErrors)
+
+	// Phase 1c: Detect mocks
+	analyzer.AnalyzeMockTypes(pass)
+
+	// Phase 1d: Detect interface method contracts
+	analyzer.AnalyzeErrorContracts(pass, localErrors)
+
 	// Phase 2: Analyze function bodies for returns
-	localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls := analyzer.AnalyzeFunctionReturns(pass, localErrors)
+	localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls := analyzer.AnalyzeFunctionReturns(pass, localErrors, nil)
 
 	// Phase 2b: Analyze closures
 	analyzer.AnalyzeClosures(pass, localErrors)
@@ -50,7 +68,7 @@ func runTestAnalyzer(pass *analysis.Pass) (interface{}, error) {
 	analyzer.ComputeInterfaceMethodFacts(pass, localFacts, localParamFlowFacts, localCallFlowFacts, interfaceImpls)
 
 	// Phase 3: Check call sites (the phase under test)
-	checker.CheckCallSites(pass, interfaceImpls)
+	checker.CheckCallSites(pass, interfaceImpls, nil)
 
 	return nil, nil
 }
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+)
+
+// returnedForms returns the forms the custom error type with the given key is
+// returned in to the variable of state, or 0 if they are not known. The forms are
+// unknown as soon as one of the sources returning the type does not know them.
+func returnedForms(state *errorVarState, key string) facts.ErrorForm {
+	var forms facts.ErrorForm
+	for _, errInfo := range state.errors {
+		if errInfo.Key() != key {
+			continue
+		}
+		if errInfo.Forms == 0 {
+			return 0
+		}
+		forms |= errInfo.Forms
+	}
+	return forms
+}
+
+// typeForm returns the form a check against t matches: a pointer type matches errors
+// returned as pointers, other types match errors returned as values. Interfaces match
+// either form and return 0.
+func typeForm(t types.Type) facts.ErrorForm {
+	switch t.Underlying().(type) {
+	case *types.Pointer:
+		return facts.FormPointer
+	case *types.Interface:
+		return 0
+	}
+	return facts.FormValue
+}
+
+func formName(form facts.ErrorForm) string {
+	if form == facts.FormPointer {
+		return "pointer"
+	}
+	return "value"
+}
+
+// reportFormMismatchedAsTarget reports errors.As(err, &target) when the target type is
+// the value form of a custom error type only returned as a pointer, or vice versa.
+// errors.As compares the dynamic type, so such a target never matches.
+func (csa *CallSiteAnalyzer) reportFormMismatchedAsTarget(target ast.Expr, state *errorVarState, key string) {
+	ptr, ok := csa.Pass.TypesInfo.TypeOf(target).(*types.Pointer)
+	if !ok {
+		return
+	}
+	csa.reportFormMismatch(target, "errors.As target of type", ptr.Elem(), state, key)
+}
+
+// reportFormMismatchedCase reports a type switch case of the value form of a custom
+// error type only returned as a pointer, or vice versa.
+func (csa *CallSiteAnalyzer) reportFormMismatchedCase(caseExpr ast.Expr, state *errorVarState, key string) {
+	t := csa.Pass.TypesInfo.TypeOf(caseExpr)
+	if t == nil {
+		return
+	}
+	csa.reportFormMismatch(caseExpr, "case", t, state, key)
+}
+
+func (csa *CallSiteAnalyzer) reportFormMismatch(node ast.Node, what string, t types.Type, state *errorVarState, key string) {
+	form := typeForm(t)
+	forms := returnedForms(state, key)
+	if form == 0 || forms == 0 || forms&form != 0 {
+		return
+	}
+	if csa.markReported(node.Pos(), key) {
+		return
+	}
+	typeString := types.TypeString(t, types.RelativeTo(csa.Pass.Pkg))
+	csa.Pass.Reportf(node.Pos(), "%s %s never matches %s, which is only returned as a %s",
+		what, typeString, key, formName(forms))
+}
@@ -0,0 +1,141 @@
+package checker
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+)
+
+// rangeIteratorErrors returns the range variable of a range-over-func loop that receives
+// the yielded errors, and the errors the iterator yields. It returns nil if the loop does
+// not range over an iterator with an IteratorErrorsFact.
+func (csa *CallSiteAnalyzer) rangeIteratorErrors(s *ast.RangeStmt) (*types.Var, []facts.ErrorInfo) {
+	pass := csa.Pass
+	t := pass.TypesInfo.TypeOf(s.X)
+	if t == nil {
+		return nil, nil
+	}
+
+	var target ast.Expr
+	switch internal.IteratorYieldErrorIndex(t) {
+	case 0:
+		target = s.Key
+	case 1:
+		target = s.Value
+	default:
+		return nil, nil
+	}
+	ident, ok := target.(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	errorVar, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok {
+		return nil, nil
+	}
+
+	errs := csa.iteratorErrors(s.X)
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return errorVar, errs
+}
+
+// iteratorErrors returns the errors yielded by the iterator expr evaluates to: the result
+// of a call of a function returning an iterator (repo.All(ctx)), or a function that is an
+// iterator itself (repo.Rows). Calls of interface methods yield the errors of all
+// implementations.
+func (csa *CallSiteAnalyzer) iteratorErrors(expr ast.Expr) []facts.ErrorInfo {
+	pass := csa.Pass
+	expr = ast.Unparen(expr)
+
+	call, isCall := expr.(*ast.CallExpr)
+	if !isCall {
+		var fn *types.Func
+		switch e := expr.(type) {
+		case *ast.Ident:
+			fn, _ = pass.TypesInfo.Uses[e].(*types.Func)
+		case *ast.SelectorExpr:
+			fn, _ = pass.TypesInfo.Uses[e.Sel].(*types.Func)
+		}
+		if fn == nil {
+			return nil
+		}
+		var iterFact facts.IteratorErrorsFact
+		if pass.ImportObjectFact(fn, &iterFact) {
+			return iterFact.Errors
+		}
+		return nil
+	}
+
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+		if ifaceType, method := csa.resolveInterfaceMethod(sel); ifaceType != nil {
+			result := &facts.IteratorErrorsFact{}
+			for _, concreteType := range csa.implementingTypes(ifaceType) {
+				concreteMethod := internal.FindMethodImplementation(concreteType, method)
+				if concreteMethod == nil {
+					continue
+				}
+				var iterFact facts.IteratorErrorsFact
+				if pass.ImportObjectFact(concreteMethod, &iterFact) {
+					for _, errInfo := range iterFact.Errors {
+						result.AddError(errInfo)
+					}
+				}
+			}
+			return result.Errors
+		}
+	}
+
+	fn := internal.GetCalledFunction(pass, call)
+	if fn == nil {
+		return nil
+	}
+	var iterFact facts.IteratorErrorsFact
+	if pass.ImportObjectFact(fn, &iterFact) {
+		return iterFact.Errors
+	}
+	return nil
+}
+
+// recordYieldParam records the yield parameter of sig if it is a range-over-func iterator
+// yielding errors, so that errors passed to yield are treated as propagated.
+func (csa *CallSiteAnalyzer) recordYieldParam(sig *types.Signature) {
+	index := internal.IteratorYieldErrorIndex(sig)
+	if index < 0 {
+		return
+	}
+	csa.yieldParams[sig.Params().At(0)] = index
+}
+
+// markYieldedErrorArgs marks the errors of tracked variables passed as the error argument
+// of a yield call (yield(v, err)) as checked. Like return err, yielding an error hands it
+// to the caller, who handles it in the body of its range loop.
+func (csa *CallSiteAnalyzer) markYieldedErrorArgs(call *ast.CallExpr, states map[*types.Var]*errorVarState) {
+	pass := csa.Pass
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return
+	}
+	yieldVar, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return
+	}
+	index, ok := csa.yieldParams[yieldVar]
+	if !ok || index >= len(call.Args) {
+		return
+	}
+	for varObj, state := range states {
+		if !csa.isVariablePropagatedInReturn(call.Args[index], varObj) {
+			continue
+		}
+		for _, errInfo := range state.errors {
+			key := errInfo.Key()
+			if state.propagatableKeys == nil || state.propagatableKeys[key] {
+				state.checked[key] = true
+			}
+		}
+	}
+}
@@ -23,14 +23,14 @@ func ThreeErrors(id string) error { // want ThreeErrors:`\[switch_propagation.Er
 
 // SwitchPropagationFalseNegative: ErrA and ErrB are checked with errors.Is
 // and propagated via return err. ErrC falls into the catch-all branch where
-// its identity is lost via fmt.Errorf with %v. Should report ErrC as unchecked.
+// its identity is lost via fmt.Errorf with %v. Should report ErrC as flattened.
 func SwitchPropagationFalseNegative() (int, error) { // want SwitchPropagationFalseNegative:`\[switch_propagation.ErrA, switch_propagation.ErrB, switch_propagation.ErrC\]`
-	err := ThreeErrors("x") // want "missing errors.Is check for switch_propagation.ErrC"
+	err := ThreeErrors("x")
 	switch {
 	case errors.Is(err, ErrA) || errors.Is(err, ErrB):
 		return 1, err
 	case err != nil:
-		return 0, fmt.Errorf("unexpected: %v", err)
+		return 0, fmt.Errorf("unexpected: %v", err) // want "switch_propagation.ErrC is flattened by %v"
 	}
 	return 0, nil
 }
@@ -65,12 +65,12 @@ func SwitchPropagationCatchAllReturn() (int, error) { // want SwitchPropagationC
 // SwitchPropagationSingleCheck: Only ErrA is checked and propagated.
 // ErrB and ErrC are unchecked.
 func SwitchPropagationSingleCheck() (int, error) { // want SwitchPropagationSingleCheck:`\[switch_propagation.ErrA, switch_propagation.ErrB, switch_propagation.ErrC\]`
-	err := ThreeErrors("x") // want "missing errors.Is check for switch_propagation.ErrB" "missing errors.Is check for switch_propagation.ErrC"
+	err := ThreeErrors("x")
 	switch {
 	case errors.Is(err, ErrA):
 		return 1, err
 	case err != nil:
-		return 0, fmt.Errorf("unexpected: %v", err)
+		return 0, fmt.Errorf("unexpected: %v", err) // want "switch_propagation.ErrB is flattened by %v" "switch_propagation.ErrC is flattened by %v"
 	}
 	return 0, nil
 }
@@ -89,12 +89,12 @@ func SwitchTagPropagation() (int, error) { // want SwitchTagPropagation:`\[switc
 // SwitchPropagationWithWrap: ErrA is checked and wrapped with %w (propagated).
 // ErrB and ErrC fall into catch-all with %v (not propagated).
 func SwitchPropagationWithWrap() (int, error) {
-	err := ThreeErrors("x") // want "missing errors.Is check for switch_propagation.ErrB" "missing errors.Is check for switch_propagation.ErrC"
+	err := ThreeErrors("x")
 	switch {
 	case errors.Is(err, ErrA):
 		return 1, fmt.Errorf("wrapped: %w", err)
 	case err != nil:
-		return 0, fmt.Errorf("unexpected: %v", err)
+		return 0, fmt.Errorf("unexpected: %v", err) // want "switch_propagation.ErrB is flattened by %v" "switch_propagation.ErrC is flattened by %v"
 	}
 	return 0, nil
 }
@@ -103,12 +103,12 @@ func SwitchPropagationWithWrap() (int, error) {
 // Only ErrA is checked via direct comparison and propagated.
 // ErrB and ErrC should be reported.
 func SwitchTagWithPropagation() (int, error) { // want SwitchTagWithPropagation:`\[switch_propagation.ErrA, switch_propagation.ErrB, switch_propagation.ErrC\]`
-	err := ThreeErrors("x") // want "missing errors.Is check for switch_propagation.ErrB" "missing errors.Is check for switch_propagation.ErrC"
+	err := ThreeErrors("x")
 	switch err {
 	case ErrA:
 		return 1, err
 	default:
-		return 0, fmt.Errorf("unexpected: %v", err)
+		return 0, fmt.Errorf("unexpected: %v", err) // want "switch_propagation.ErrB is flattened by %v" "switch_propagation.ErrC is flattened by %v"
 	}
 }
 
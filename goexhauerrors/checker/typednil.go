@@ -0,0 +1,23 @@
+package checker
+
+import (
+	"go/types"
+
+	ssaanalysis "github.com/YuitoSato/goexhauerrors/goexhauerrors/ssa"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+)
+
+// reportTypedNilReturns reports return statements converting a custom error pointer
+// (*MyError) that may be nil to an interface result (error). Callers checking
+// err != nil then see an error where there is none.
+func (csa *CallSiteAnalyzer) reportTypedNilReturns() {
+	ssaResult, ok := csa.Pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	if !ok {
+		return
+	}
+	qualifier := types.RelativeTo(csa.Pass.Pkg)
+	for _, ret := range ssaanalysis.TypedNilReturns(ssaResult) {
+		csa.Pass.Reportf(ret.Pos, "%s returned as %s is not nil when the pointer is nil",
+			types.TypeString(ret.From, qualifier), types.TypeString(ret.To, qualifier))
+	}
+}
@@ -0,0 +1,45 @@
+package checker
+
+import (
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/analysis"
+)
+
+// methodFlowErrors returns the errors of the method name of t, the type of the argument
+// of a method flow (Fetch(UserRepo{}, id) with func Fetch[R Repository](r R, id int) error).
+// A concrete type argument narrows the errors to its own method. An interface or type
+// parameter yields the errors its package computed for the interface method.
+func methodFlowErrors(pass *analysis.Pass, t types.Type, name string) []facts.ErrorInfo {
+	if t == nil {
+		return nil
+	}
+
+	if iface := internal.GetInterfaceType(t); iface != nil {
+		method := internal.InterfaceMethodByName(iface, name)
+		if method == nil {
+			return nil
+		}
+		var contract facts.ErrorContractFact
+		if pass.ImportObjectFact(method, &contract) {
+			return contract.Errors
+		}
+		var ifaceFact facts.InterfaceMethodFact
+		if pass.ImportObjectFact(method, &ifaceFact) {
+			return ifaceFact.Errors
+		}
+		return nil
+	}
+
+	method := internal.LookupMethod(t, name)
+	if method == nil {
+		return nil
+	}
+	var fnFact facts.FunctionErrorsFact
+	if pass.ImportObjectFact(method, &fnFact) {
+		return fnFact.Errors
+	}
+	return nil
+}
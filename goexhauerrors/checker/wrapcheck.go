@@ -0,0 +1,226 @@
+package checker
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isWrapUnsafe reports whether the error with the given key may reach the variable of
+// state wrapped in another error, so that comparing the variable with == or a type
+// switch never matches it. This is the case when it is returned wrapped (%w or a
+// wrapper type), or when a returned sentinel wraps it (a hierarchical sentinel).
+func (csa *CallSiteAnalyzer) isWrapUnsafe(state *errorVarState, key string) bool {
+	for _, errInfo := range state.errors {
+		if errInfo.Key() == key {
+			if errInfo.Wrapped || errInfo.WrappedBy != "" {
+				return true
+			}
+			continue
+		}
+		for _, ancestor := range csa.sentinels.Ancestors(errInfo) {
+			if ancestor == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// markReported records a diagnostic for key at pos and reports whether it was already recorded.
+func (csa *CallSiteAnalyzer) markReported(pos token.Pos, key string) bool {
+	if csa.reported[pos][key] {
+		return true
+	}
+	if csa.reported[pos] == nil {
+		csa.reported[pos] = make(map[string]bool)
+	}
+	csa.reported[pos][key] = true
+	return false
+}
+
+// reportWrapUnsafeComparison reports err == ErrX when ErrX may arrive wrapped,
+// with a suggested fix to use errors.Is(err, ErrX) instead.
+func (csa *CallSiteAnalyzer) reportWrapUnsafeComparison(cmp *ast.BinaryExpr, errExpr, target ast.Expr, state *errorVarState, key string) {
+	if !csa.isWrapUnsafe(state, key) || csa.markReported(cmp.Pos(), key) {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     cmp.Pos(),
+		End:     cmp.End(),
+		Message: fmt.Sprintf("%s may be returned wrapped, which %s does not match; use errors.Is", key, cmp.Op),
+	}
+	if name, edits, ok := csa.errorsImport(cmp.Pos()); ok {
+		check := fmt.Sprintf("%s.Is(%s, %s)", name, types.ExprString(errExpr), types.ExprString(target))
+		if cmp.Op == token.NEQ {
+			check = "!" + check
+		}
+		edits = append(edits, analysis.TextEdit{Pos: cmp.Pos(), End: cmp.End(), NewText: []byte(check)})
+		diag.SuggestedFixes = []analysis.SuggestedFix{{Message: "Use errors.Is", TextEdits: edits}}
+	}
+	csa.Pass.Report(diag)
+}
+
+// reportWrapUnsafeTypeSwitch reports a type switch case of an error type that may arrive
+// wrapped, with a suggested fix to turn the switch into a switch of errors.As calls.
+func (csa *CallSiteAnalyzer) reportWrapUnsafeTypeSwitch(s *ast.TypeSwitchStmt, caseExpr, errExpr ast.Expr, state *errorVarState, key string) {
+	if !csa.isWrapUnsafe(state, key) || csa.markReported(caseExpr.Pos(), key) {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     caseExpr.Pos(),
+		End:     caseExpr.End(),
+		Message: fmt.Sprintf("%s may be returned wrapped, which a type switch does not match; use errors.As", key),
+	}
+	if fix, ok := csa.typeSwitchFix(s, errExpr); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	csa.Pass.Report(diag)
+}
+
+// reportWrapUnsafeSwitchCase reports a case of a switch on the error variable (switch err)
+// comparing it with an error that may arrive wrapped, with a suggested fix to turn the
+// switch into a switch of errors.Is calls.
+func (csa *CallSiteAnalyzer) reportWrapUnsafeSwitchCase(s *ast.SwitchStmt, caseExpr ast.Expr, state *errorVarState, key string) {
+	if !csa.isWrapUnsafe(state, key) || csa.markReported(caseExpr.Pos(), key) {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     caseExpr.Pos(),
+		End:     caseExpr.End(),
+		Message: fmt.Sprintf("%s may be returned wrapped, which a switch does not match; use errors.Is", key),
+	}
+	if fix, ok := csa.switchFix(s); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	csa.Pass.Report(diag)
+}
+
+// switchFix rewrites switch err { case ErrX: } into switch { case errors.Is(err, ErrX): }.
+func (csa *CallSiteAnalyzer) switchFix(s *ast.SwitchStmt) (analysis.SuggestedFix, bool) {
+	name, edits, ok := csa.errorsImport(s.Pos())
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	// Drop the tag: switch err { -> switch {
+	edits = append(edits, analysis.TextEdit{Pos: s.Tag.Pos(), End: s.Tag.End()})
+
+	errText := types.ExprString(s.Tag)
+	for _, stmt := range s.Body.List {
+		cc, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		for _, expr := range cc.List {
+			cond := fmt.Sprintf("%s.Is(%s, %s)", name, errText, types.ExprString(expr))
+			if csa.Pass.TypesInfo.Types[expr].IsNil() {
+				cond = errText + " == nil"
+			}
+			edits = append(edits, analysis.TextEdit{Pos: expr.Pos(), End: expr.End(), NewText: []byte(cond)})
+		}
+	}
+	return analysis.SuggestedFix{Message: "Use errors.Is", TextEdits: edits}, true
+}
+
+// typeSwitchFix rewrites switch err.(type) { case *ErrX: } into
+// switch { case errors.As(err, new(*ErrX)): }. A switch binding the value
+// (switch v := err.(type)) is not rewritten, since v has a different type in every case.
+func (csa *CallSiteAnalyzer) typeSwitchFix(s *ast.TypeSwitchStmt, errExpr ast.Expr) (analysis.SuggestedFix, bool) {
+	if _, ok := s.Assign.(*ast.ExprStmt); !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	name, edits, ok := csa.errorsImport(s.Pos())
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	// Drop the type assertion: switch err.(type) { -> switch {
+	start := s.Assign.Pos()
+	if s.Init == nil {
+		start = s.Switch + token.Pos(len("switch"))
+	}
+	edits = append(edits, analysis.TextEdit{Pos: start, End: s.Assign.End()})
+
+	errText := types.ExprString(errExpr)
+	for _, stmt := range s.Body.List {
+		cc, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		for _, expr := range cc.List {
+			cond := fmt.Sprintf("%s.As(%s, new(%s))", name, errText, types.ExprString(expr))
+			if csa.Pass.TypesInfo.Types[expr].IsNil() {
+				cond = errText + " == nil"
+			}
+			edits = append(edits, analysis.TextEdit{Pos: expr.Pos(), End: expr.End(), NewText: []byte(cond)})
+		}
+	}
+	return analysis.SuggestedFix{Message: "Use errors.As", TextEdits: edits}, true
+}
+
+// errorsImport returns the name the file containing pos refers to the standard errors
+// package by, and the edit adding the import if the file does not import it yet.
+// It reports false if the name errors is already taken by something else.
+func (csa *CallSiteAnalyzer) errorsImport(pos token.Pos) (string, []analysis.TextEdit, bool) {
+	pass := csa.Pass
+	var file *ast.File
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return "", nil, false
+	}
+
+	for _, imp := range file.Imports {
+		pkgName := pass.TypesInfo.PkgNameOf(imp)
+		if pkgName == nil {
+			continue
+		}
+		if pkgName.Imported().Path() == "errors" {
+			if pkgName.Name() == "_" || pkgName.Name() == "." {
+				return "", nil, false
+			}
+			return pkgName.Name(), nil, true
+		}
+		if pkgName.Name() == "errors" {
+			return "", nil, false
+		}
+	}
+	if pass.Pkg.Scope().Lookup("errors") != nil {
+		return "", nil, false
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		if genDecl.Lparen.IsValid() {
+			return "errors", []analysis.TextEdit{{
+				Pos:     genDecl.Lparen + 1,
+				End:     genDecl.Lparen + 1,
+				NewText: []byte("\n\t\"errors\""),
+			}}, true
+		}
+		return "errors", []analysis.TextEdit{{
+			Pos:     genDecl.Pos(),
+			End:     genDecl.Pos(),
+			NewText: []byte("import \"errors\"\n"),
+		}}, true
+	}
+	return "errors", []analysis.TextEdit{{
+		Pos:     file.Name.End(),
+		End:     file.Name.End(),
+		NewText: []byte("\n\nimport \"errors\""),
+	}}, true
+}
@@ -32,7 +32,8 @@ func NewLocalErrors() *LocalErrors {
 // It detects:
 // 1. var Err* = errors.New("...") pattern (sentinel errors)
 // 2. var Err* = &ErrorType{...} pattern (composite literal sentinels)
-// 3. Custom error types (structs implementing error interface)
+// 3. Error variables assigned fresh errors in init() or by local helpers
+// 4. Custom error types (structs implementing error interface)
 func DetectLocalErrors(pass *analysis.Pass) *LocalErrors {
 	result := NewLocalErrors()
 
@@ -44,15 +45,28 @@ func DetectLocalErrors(pass *analysis.Pass) *LocalErrors {
 	// Detect custom error types
 	detectCustomErrorTypes(pass, result)
 
+	// Detect wrapper error types whose Unwrap returns struct fields
+	detectUnwrapFields(pass, insp, result)
+
 	return result
 }
 
-// detectSentinelVars finds var Err* = errors.New("...") patterns.
+// detectSentinelVars finds var Err* = errors.New("...") patterns, and sentinels
+// defined by wrapping them (var ErrUserNotFound = fmt.Errorf("user: %w", ErrNotFound)).
 func detectSentinelVars(pass *analysis.Pass, insp *inspector.Inspector, result *LocalErrors) {
 	nodeFilter := []ast.Node{
 		(*ast.GenDecl)(nil),
 	}
 
+	// Wrapping sentinels are resolved once all plain sentinels are known,
+	// since their parents may be declared after them
+	wrapping := make(map[*types.Var][]ast.Expr)
+	var wrappingOrder []*types.Var
+	// Aliases (var ErrNotFound = storage.ErrNotFound) are resolved last, since they may
+	// refer to sentinels of both kinds, or to other aliases
+	aliases := make(map[*types.Var]*types.Var)
+	var aliasOrder []*types.Var
+
 	insp.Preorder(nodeFilter, func(n ast.Node) {
 		genDecl := n.(*ast.GenDecl)
 		if genDecl.Tok != token.VAR {
@@ -109,11 +123,158 @@ func detectSentinelVars(pass *analysis.Pass, insp *inspector.Inspector, result *
 							}
 							pass.ExportObjectFact(varObj, fact)
 						}
+					} else if call, ok := initExpr.(*ast.CallExpr); ok {
+						if args, _ := internal.WrappedArgs(pass, call); len(args) > 0 {
+							wrapping[varObj] = args
+							wrappingOrder = append(wrappingOrder, varObj)
+						}
+					} else if target := packageVar(pass, initExpr); target != nil && name.Name != "_" {
+						aliases[varObj] = target
+						aliasOrder = append(aliasOrder, varObj)
 					}
 				}
 			}
 		}
 	})
+
+	detectInitializedSentinels(pass, result)
+	detectWrappingSentinels(pass, wrapping, wrappingOrder, result)
+	detectSentinelAliases(pass, aliases, aliasOrder, result)
+}
+
+// detectSentinelAliases exports an ErrorFact naming the original sentinel for the
+// variables in order that alias another sentinel (var ErrNotFound = storage.ErrNotFound).
+// The alias holds the same error value, so errors.Is(err, facade.ErrNotFound) checks
+// the original key. Unlike other sentinels, unexported aliases get the fact too, since
+// it is what maps them to their original. Aliases of aliases are resolved in rounds.
+// An alias of a hierarchical sentinel also gets its ErrorParentFact.
+func detectSentinelAliases(pass *analysis.Pass, aliases map[*types.Var]*types.Var, order []*types.Var, result *LocalErrors) {
+	resolved := make(map[*types.Var]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, varObj := range order {
+			if resolved[varObj] {
+				continue
+			}
+			target := aliases[varObj]
+			fact := &facts.ErrorFact{}
+			if result.Vars[target] {
+				fact.Name = target.Name()
+				fact.PkgPath = pass.Pkg.Path()
+			} else if !pass.ImportObjectFact(target, fact) {
+				continue
+			}
+
+			resolved[varObj] = true
+			changed = true
+			pass.ExportObjectFact(varObj, fact)
+			// The ancestors of a hierarchical sentinel are kept with the alias, for callers
+			// that import the original package only indirectly
+			parentFact := &facts.ErrorParentFact{}
+			if pass.ImportObjectFact(target, parentFact) {
+				pass.ExportObjectFact(varObj, parentFact)
+			}
+		}
+	}
+}
+
+// detectWrappingSentinels registers the variables in order whose initializer wraps
+// another sentinel, and exports an ErrorParentFact with their ancestors.
+// A parent may itself be a wrapping sentinel, so variables are resolved in rounds
+// until no more of them can be resolved; the others wrap something that is not a sentinel.
+func detectWrappingSentinels(pass *analysis.Pass, wrapping map[*types.Var][]ast.Expr, order []*types.Var, result *LocalErrors) {
+	ancestors := make(map[*types.Var][]string)
+	for changed := true; changed; {
+		changed = false
+		for _, varObj := range order {
+			if result.Vars[varObj] {
+				continue
+			}
+			parents, ok := sentinelAncestors(pass, wrapping[varObj], wrapping, ancestors, result)
+			if !ok || len(parents) == 0 {
+				continue
+			}
+
+			result.Vars[varObj] = true
+			ancestors[varObj] = parents
+			changed = true
+			if varObj.Exported() {
+				pass.ExportObjectFact(varObj, &facts.ErrorFact{
+					Name:    varObj.Name(),
+					PkgPath: pass.Pkg.Path(),
+				})
+			}
+			pass.ExportObjectFact(varObj, &facts.ErrorParentFact{Ancestors: parents})
+		}
+	}
+}
+
+// sentinelAncestors returns the keys of the sentinels among the wrapped arguments,
+// followed by their own ancestors. It reports false if an argument is a wrapping
+// sentinel of this package that is not resolved yet.
+func sentinelAncestors(pass *analysis.Pass, args []ast.Expr, wrapping map[*types.Var][]ast.Expr, ancestors map[*types.Var][]string, result *LocalErrors) ([]string, bool) {
+	var parents, inherited []string
+	for _, arg := range args {
+		parent := packageVar(pass, arg)
+		if parent == nil {
+			continue
+		}
+		if parent.Pkg() == pass.Pkg {
+			if !result.Vars[parent] {
+				if _, pending := wrapping[parent]; pending {
+					return nil, false
+				}
+				continue
+			}
+			parents = appendUnique(parents, parent.Pkg().Path()+"."+parent.Name())
+			inherited = append(inherited, ancestors[parent]...)
+			continue
+		}
+
+		var errorFact facts.ErrorFact
+		if !pass.ImportObjectFact(parent, &errorFact) {
+			continue
+		}
+		parents = appendUnique(parents, errorFact.Key())
+		var parentFact facts.ErrorParentFact
+		if pass.ImportObjectFact(parent, &parentFact) {
+			inherited = append(inherited, parentFact.Ancestors...)
+		}
+	}
+	if len(parents) == 0 {
+		return nil, true
+	}
+	for _, key := range inherited {
+		parents = appendUnique(parents, key)
+	}
+	return parents, true
+}
+
+// packageVar returns the package-level variable expr refers to, or nil.
+func packageVar(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+	varObj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || varObj.Pkg() == nil || varObj.Parent() != varObj.Pkg().Scope() {
+		return nil
+	}
+	return varObj
+}
+
+func appendUnique(keys []string, key string) []string {
+	for _, k := range keys {
+		if k == key {
+			return keys
+		}
+	}
+	return append(keys, key)
 }
 
 // detectCustomErrorTypes finds struct types that implement the error interface.
@@ -155,6 +316,85 @@ func detectCustomErrorTypes(pass *analysis.Pass, result *LocalErrors) {
 	}
 }
 
+// detectUnwrapFields finds Unwrap() error / Unwrap() []error methods of custom error
+// types that return receiver fields and exports an UnwrapFact for the type.
+// Supported patterns:
+// - return e.Err
+// - return []error{e.Err, e.Cause}
+func detectUnwrapFields(pass *analysis.Pass, insp *inspector.Inspector, result *LocalErrors) {
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		funcDecl := n.(*ast.FuncDecl)
+		if funcDecl.Recv == nil || funcDecl.Name.Name != "Unwrap" || funcDecl.Body == nil {
+			return
+		}
+		if len(funcDecl.Recv.List) != 1 || len(funcDecl.Recv.List[0].Names) != 1 {
+			return
+		}
+
+		fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		sig := fn.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 || !isErrorOrErrorSlice(sig.Results().At(0).Type()) {
+			return
+		}
+		namedType := internal.ExtractNamedType(sig.Recv().Type())
+		if namedType == nil || !result.Types[namedType.Obj()] {
+			return
+		}
+		recvVar := pass.TypesInfo.Defs[funcDecl.Recv.List[0].Names[0]]
+		if recvVar == nil {
+			return
+		}
+
+		fact := &facts.UnwrapFact{}
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			ret, ok := n.(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				return true
+			}
+			exprs := []ast.Expr{ret.Results[0]}
+			if comp, ok := ret.Results[0].(*ast.CompositeLit); ok {
+				exprs = comp.Elts
+			}
+			for _, expr := range exprs {
+				sel, ok := expr.(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				ident, ok := sel.X.(*ast.Ident)
+				if !ok || pass.TypesInfo.Uses[ident] != recvVar {
+					continue
+				}
+				if field, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Var); !ok || !field.IsField() {
+					continue
+				}
+				if !fact.HasField(sel.Sel.Name) {
+					fact.Fields = append(fact.Fields, sel.Sel.Name)
+				}
+			}
+			return true
+		})
+
+		if len(fact.Fields) > 0 {
+			pass.ExportObjectFact(namedType.Obj(), fact)
+		}
+	})
+}
+
+// isErrorOrErrorSlice checks if the type is error or []error.
+func isErrorOrErrorSlice(t types.Type) bool {
+	if slice, ok := t.(*types.Slice); ok {
+		return internal.IsErrorType(slice.Elem())
+	}
+	return internal.IsErrorType(t)
+}
+
 // isErrorOrImplementsError checks if the type is the error interface
 // or if it implements the error interface (for concrete error types).
 func isErrorOrImplementsError(t types.Type) bool {
@@ -175,20 +415,21 @@ func isErrorOrImplementsError(t types.Type) bool {
 
 // isCallSentinelInit checks if the expression is a function call sentinel init.
 // Supported patterns:
-// - errors.New("...")
-// - fmt.Errorf("...") without %w
+// - errors.New("...") and equivalent constructors (pkg/errors, cockroachdb/errors, xerrors)
+// - fmt.Errorf("...") and equivalent format-based wrappers without %w
 func isCallSentinelInit(pass *analysis.Pass, expr ast.Expr) bool {
 	call, ok := expr.(*ast.CallExpr)
 	if !ok {
 		return false
 	}
-	if isErrorsNewCall(pass, call) {
-		return true
+	fn := internal.GetCalledFunction(pass, call)
+	if fn == nil {
+		return false
 	}
-	if isFmtErrorfWithoutWrap(pass, call) {
+	if internal.IsErrorConstructor(fn) {
 		return true
 	}
-	return false
+	return isFormatWrapperWithoutWrap(fn, call)
 }
 
 // isErrorCompositeLiteral checks if the expression is a composite literal
@@ -221,63 +462,20 @@ func isErrorCompositeLiteral(pass *analysis.Pass, expr ast.Expr) bool {
 	return types.Implements(ptrType, errorInterface)
 }
 
-// isErrorsNewCall checks if the call is errors.New().
-func isErrorsNewCall(pass *analysis.Pass, call *ast.CallExpr) bool {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	if sel.Sel.Name != "New" {
-		return false
-	}
-
-	ident, ok := sel.X.(*ast.Ident)
-	if !ok {
-		return false
-	}
-
-	obj := pass.TypesInfo.Uses[ident]
-	pkgName, ok := obj.(*types.PkgName)
-	if !ok {
-		return false
-	}
-
-	return pkgName.Imported().Path() == "errors"
-}
-
-// isFmtErrorfWithoutWrap checks if the call is fmt.Errorf without %w.
-func isFmtErrorfWithoutWrap(pass *analysis.Pass, call *ast.CallExpr) bool {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	if sel.Sel.Name != "Errorf" {
-		return false
-	}
-
-	ident, ok := sel.X.(*ast.Ident)
-	if !ok {
-		return false
-	}
-
-	obj := pass.TypesInfo.Uses[ident]
-	pkgName, ok := obj.(*types.PkgName)
-	if !ok {
-		return false
-	}
-
-	if pkgName.Imported().Path() != "fmt" {
+// isFormatWrapperWithoutWrap checks if the call is fmt.Errorf (or an equivalent
+// format-based wrapper) without %w, which creates a new error instead of wrapping one.
+func isFormatWrapperWithoutWrap(fn *types.Func, call *ast.CallExpr) bool {
+	w, ok := internal.LookupWrapper(fn)
+	if !ok || w.ArgIndex >= 0 || w.FormatIndex < 0 {
 		return false
 	}
 
 	// Check format string for %w
-	if len(call.Args) < 1 {
+	if w.FormatIndex >= len(call.Args) {
 		return true
 	}
 
-	formatStr := internal.ExtractStringLiteral(call.Args[0])
+	formatStr := internal.ExtractStringLiteral(call.Args[w.FormatIndex])
 	if formatStr == "" {
 		return true // Can't determine, assume no %w
 	}
@@ -60,9 +60,19 @@ func TestDetectLocalErrors_Sentinel(t *testing.T) {
 				t.Error("NotAnError should not be detected as sentinel var")
 			}
 
-			// ErrWrapped: fmt.Errorf with %w → NOT detected
-			if varNames["ErrWrapped"] {
-				t.Error("ErrWrapped should not be detected (uses %w)")
+			// ErrWrapped: fmt.Errorf with %w of a sentinel → detected
+			if !varNames["ErrWrapped"] {
+				t.Error("expected ErrWrapped to be detected as sentinel var (wraps someErr)")
+			}
+
+			// ErrWrappedTwice: wraps another wrapping sentinel → detected
+			if !varNames["ErrWrappedTwice"] {
+				t.Error("expected ErrWrappedTwice to be detected as sentinel var (wraps ErrWrapped)")
+			}
+
+			// ErrWrapsPlain: fmt.Errorf with %w of a non-sentinel → NOT detected
+			if varNames["ErrWrapsPlain"] {
+				t.Error("ErrWrapsPlain should not be detected (wraps a non-sentinel)")
 			}
 
 			// Verify facts: exported vars should have facts, unexported should not
@@ -260,9 +270,9 @@ func TestDetectLocalErrors_SentinelVarCount(t *testing.T) {
 		Run: func(pass *analysis.Pass) (interface{}, error) {
 			result := detector.DetectLocalErrors(pass)
 
-			// Expected sentinels: ErrNotFound, ErrTimeout, errPrivate, someErr
-			// NOT: NotAnError (string), ErrWrapped (%w)
-			expectedCount := 4
+			// Expected sentinels: ErrNotFound, ErrTimeout, errPrivate, someErr, ErrWrapped, ErrWrappedTwice
+			// NOT: NotAnError (string), plainErr (no init), ErrWrapsPlain (wraps a non-sentinel)
+			expectedCount := 6
 			if len(result.Vars) != expectedCount {
 				names := make([]string, 0, len(result.Vars))
 				for v := range result.Vars {
@@ -314,3 +324,54 @@ func TestDetectLocalErrors_CustomTypeCount(t *testing.T) {
 // Ensure types.Var and types.TypeName are used (avoid import errors).
 var _ *types.Var
 var _ *types.TypeName
+
+func TestDetectLocalErrors_UnwrapFact(t *testing.T) {
+	testAnalyzer := &analysis.Analyzer{
+		Name:     "test_unwrap_facts",
+		Doc:      "test unwrap fact content",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			detector.DetectLocalErrors(pass)
+
+			tests := []struct {
+				typeName string
+				want     []string
+			}{
+				{"OpError", []string{"Err"}},
+				{"MultiError", []string{"Primary", "Secondary"}},
+				{"DynamicError", nil},
+			}
+			for _, tt := range tests {
+				obj := pass.Pkg.Scope().Lookup(tt.typeName)
+				if obj == nil {
+					t.Fatalf("%s not found in scope", tt.typeName)
+				}
+				var fact facts.UnwrapFact
+				hasFact := pass.ImportObjectFact(obj, &fact)
+				if tt.want == nil {
+					if hasFact {
+						t.Errorf("did not expect UnwrapFact for %s, got %v", tt.typeName, fact.Fields)
+					}
+					continue
+				}
+				if !hasFact {
+					t.Errorf("expected UnwrapFact for %s", tt.typeName)
+					continue
+				}
+				if len(fact.Fields) != len(tt.want) {
+					t.Errorf("UnwrapFact for %s = %v, want %v", tt.typeName, fact.Fields, tt.want)
+					continue
+				}
+				for i := range tt.want {
+					if fact.Fields[i] != tt.want[i] {
+						t.Errorf("UnwrapFact for %s = %v, want %v", tt.typeName, fact.Fields, tt.want)
+					}
+				}
+			}
+
+			return nil, nil
+		},
+	}
+
+	analysistest.Run(t, testdataDir(), testAnalyzer, "unwrap")
+}
@@ -0,0 +1,171 @@
+package detector
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// detectInitializedSentinels finds package-level error variables that are not initialized
+// with errors.New or a composite literal in their declaration, but assigned fresh errors
+// elsewhere: in init functions (func init() { ErrX = errors.New("x") }), or by calls of
+// helpers of this package (var ErrX = newErr("x")). A variable is a sentinel only if every
+// assignment to it in the package is a fresh error made during initialization, and its
+// address is never taken: a variable also assigned by other functions holds runtime state
+// (LastErr = errors.New(msg)). The assignments are found in SSA, including the package
+// initializer.
+func detectInitializedSentinels(pass *analysis.Pass, result *LocalErrors) {
+	ssaResult, ok := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	if !ok {
+		return
+	}
+
+	initFn := ssaResult.Pkg.Func("init")
+	funcs := ssaResult.SrcFuncs
+	if initFn != nil {
+		funcs = append(append([]*ssa.Function(nil), funcs...), initFn)
+	}
+
+	stores := make(map[*types.Var][]ssa.Value)
+	escaped := make(map[*types.Var]bool)
+	for _, fn := range funcs {
+		initializes := fn == initFn || isInitFunc(fn)
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				for _, op := range instr.Operands(nil) {
+					global, ok := (*op).(*ssa.Global)
+					if !ok || global.Pkg != ssaResult.Pkg {
+						continue
+					}
+					varObj, ok := global.Object().(*types.Var)
+					if !ok {
+						continue
+					}
+					switch i := instr.(type) {
+					case *ssa.Store:
+						if i.Addr == global && initializes {
+							stores[varObj] = append(stores[varObj], i.Val)
+							continue
+						}
+					case *ssa.UnOp:
+						if i.Op == token.MUL {
+							continue
+						}
+					}
+					escaped[varObj] = true
+				}
+			}
+		}
+	}
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		varObj, ok := scope.Lookup(name).(*types.Var)
+		if !ok || result.Vars[varObj] || escaped[varObj] || len(stores[varObj]) == 0 || !internal.IsErrorType(varObj.Type()) {
+			continue
+		}
+		fresh := true
+		for _, val := range stores[varObj] {
+			if !isFreshError(val, ssaResult.Pkg, make(map[*ssa.Function]bool)) {
+				fresh = false
+				break
+			}
+		}
+		if !fresh {
+			continue
+		}
+
+		result.Vars[varObj] = true
+		if varObj.Exported() {
+			pass.ExportObjectFact(varObj, &facts.ErrorFact{
+				Name:    name,
+				PkgPath: pass.Pkg.Path(),
+			})
+		}
+	}
+}
+
+// isInitFunc reports whether fn is a func init() of the package, or a closure in one.
+func isInitFunc(fn *ssa.Function) bool {
+	for fn.Parent() != nil {
+		fn = fn.Parent()
+	}
+	return fn.Signature.Recv() == nil && strings.HasPrefix(fn.Name(), "init#")
+}
+
+// isFreshError reports whether val is a new error value: the result of an error
+// constructor, of a format-based wrapper without %w, of a helper of pkg returning only
+// fresh errors, or a custom error created with a composite literal.
+func isFreshError(val ssa.Value, pkg *ssa.Package, visited map[*ssa.Function]bool) bool {
+	switch v := val.(type) {
+	case *ssa.MakeInterface:
+		_, ok := v.X.(*ssa.Alloc)
+		return ok
+
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if !isFreshError(edge, pkg, visited) {
+				return false
+			}
+		}
+		return len(v.Edges) > 0
+
+	case *ssa.Call:
+		callee := v.Call.StaticCallee()
+		if callee == nil {
+			return false
+		}
+		if fn, ok := callee.Object().(*types.Func); ok {
+			if internal.IsErrorConstructor(fn) {
+				return true
+			}
+			if w, ok := internal.LookupWrapper(fn); ok {
+				return w.ArgIndex < 0 && w.FormatIndex >= 0 && !formatWraps(v.Call.Args, w.FormatIndex)
+			}
+		}
+		return callee.Pkg == pkg && returnsFreshErrors(callee, pkg, visited)
+	}
+	return false
+}
+
+// returnsFreshErrors reports whether every return of the helper fn, which has a single
+// error result, returns a fresh error.
+func returnsFreshErrors(fn *ssa.Function, pkg *ssa.Package, visited map[*ssa.Function]bool) bool {
+	if visited[fn] || len(fn.Blocks) == 0 || fn.Signature.Results().Len() != 1 {
+		return false
+	}
+	visited[fn] = true
+
+	found := false
+	for _, block := range fn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		if !isFreshError(ret.Results[0], pkg, visited) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// formatWraps reports whether the constant format argument of a format-based wrapper
+// call contains %w. A format that is not constant is assumed not to.
+func formatWraps(args []ssa.Value, formatIndex int) bool {
+	if formatIndex >= len(args) {
+		return false
+	}
+	c, ok := args[formatIndex].(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+		return false
+	}
+	return strings.Contains(constant.StringVal(c.Value), "%w")
+}
@@ -14,7 +14,13 @@ var errPrivate = errors.New("private")
 var NotAnError = "string"
 
 var someErr = errors.New("some")
-var ErrWrapped = fmt.Errorf("wrap: %w", someErr)
+var ErrWrapped = fmt.Errorf("wrap: %w", someErr) // want ErrWrapped:"sentinel.ErrWrapped" ErrWrapped:`wraps:\[sentinel.someErr\]`
+
+var ErrWrappedTwice = fmt.Errorf("twice: %w", ErrWrapped) // want ErrWrappedTwice:"sentinel.ErrWrappedTwice" ErrWrappedTwice:`wraps:\[sentinel.ErrWrapped,sentinel.someErr\]`
+
+var plainErr error
+
+var ErrWrapsPlain = fmt.Errorf("plain: %w", plainErr)
 
 // Ensure errPrivate is used to avoid compile error.
 var _ = errPrivate
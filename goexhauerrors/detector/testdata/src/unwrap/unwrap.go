@@ -0,0 +1,32 @@
+package unwrap
+
+import "errors"
+
+// OpError wraps a single error.
+type OpError struct { // want OpError:"unwrap.OpError" OpError:"unwraps:Err"
+	Op  string
+	Err error
+}
+
+func (e *OpError) Error() string { return e.Op + ": " + e.Err.Error() }
+
+func (e *OpError) Unwrap() error { return e.Err }
+
+// MultiError wraps two errors.
+type MultiError struct { // want MultiError:"unwrap.MultiError" MultiError:"unwraps:Primary,Secondary"
+	Primary   error
+	Secondary error
+}
+
+func (e MultiError) Error() string { return "multi" }
+
+func (e MultiError) Unwrap() []error { return []error{e.Primary, e.Secondary} }
+
+// DynamicError unwraps to something other than a field.
+type DynamicError struct { // want DynamicError:"unwrap.DynamicError"
+	Msg string
+}
+
+func (e *DynamicError) Error() string { return e.Msg }
+
+func (e *DynamicError) Unwrap() error { return errors.New(e.Msg) }
@@ -0,0 +1,80 @@
+package goexhauerrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/errset"
+	"golang.org/x/tools/go/analysis"
+)
+
+// snapshotDir and baselineDir select the mode of DiffAnalyzer.
+var (
+	snapshotDir string
+	baselineDir string
+)
+
+func init() {
+	DiffAnalyzer.Flags.StringVar(&snapshotDir, "snapshot", "",
+		"directory to write the exported error sets of each analyzed package to")
+	DiffAnalyzer.Flags.StringVar(&baselineDir, "baseline", "",
+		"directory of a previous snapshot to compare the exported error sets against")
+}
+
+// DiffAnalyzer detects changes to the error sets of exported functions between two revisions.
+// Run it with -snapshot on the old revision and with -baseline on the new one: every error
+// added to or removed from an exported function or interface method is reported at its
+// declaration, since callers that check the old error set are no longer exhaustive.
+var DiffAnalyzer = &analysis.Analyzer{
+	Name:     "exhaustiveerrorsdiff",
+	Doc:      "reports errors added to or removed from exported functions since a baseline snapshot",
+	Run:      runDiff,
+	Requires: []*analysis.Analyzer{Analyzer},
+}
+
+func runDiff(pass *analysis.Pass) (interface{}, error) {
+	if snapshotDir == "" && baselineDir == "" {
+		return nil, errors.New("one of -snapshot or -baseline must be set")
+	}
+
+	current := pass.ResultOf[Analyzer].(*errset.Snapshot)
+
+	// Read the baseline before writing the snapshot, which may replace it in the same directory
+	var baseline *errset.Snapshot
+	if baselineDir != "" {
+		var err error
+		baseline, err = errset.Read(baselineDir, pass.Pkg.Path())
+		if err != nil {
+			return nil, fmt.Errorf("reading error set baseline: %w", err)
+		}
+	}
+
+	if snapshotDir != "" {
+		if err := errset.Write(snapshotDir, current); err != nil {
+			return nil, fmt.Errorf("writing error set snapshot: %w", err)
+		}
+	}
+
+	if baseline == nil {
+		// No baseline, or a new package: nothing to compare against
+		return nil, nil
+	}
+
+	for _, change := range errset.Diff(baseline, current) {
+		obj := errset.Lookup(pass.Pkg, change.Function)
+		if obj == nil {
+			continue
+		}
+		if len(change.Added) > 0 {
+			pass.Reportf(obj.Pos(), "%s returns errors added since baseline: %s",
+				change.Function, strings.Join(change.Added, ", "))
+		}
+		if len(change.Removed) > 0 {
+			pass.Reportf(obj.Pos(), "%s no longer returns errors since baseline: %s",
+				change.Function, strings.Join(change.Removed, ", "))
+		}
+	}
+
+	return nil, nil
+}
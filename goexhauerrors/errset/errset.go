@@ -0,0 +1,180 @@
+package errset
+
+import (
+	"encoding/json"
+	"errors"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Snapshot records the errors each exported function, method and interface method
+// of a package can return. It is the unit compared between two revisions.
+type Snapshot struct {
+	Package   string              `json:"package"`
+	Functions map[string][]string `json:"functions"` // "Func" or "Type.Method" -> sorted error keys
+}
+
+// Change describes how the error set of one function differs from the baseline.
+type Change struct {
+	Function string   // "Func" or "Type.Method"
+	Added    []string // Error keys returned now but not in the baseline
+	Removed  []string // Error keys returned in the baseline but not now
+}
+
+// Collect builds a snapshot of the exported error sets of the package under analysis.
+// It must be called from the goexhauerrors analyzer itself, after all facts of the
+// package have been exported, because only that analyzer can read its own facts.
+// Every exported function with an error result is recorded, even when its error set
+// is empty, so that a later run can tell an emptied error set from a removed function.
+func Collect(pass *analysis.Pass) *Snapshot {
+	s := &Snapshot{
+		Package:   pass.Pkg.Path(),
+		Functions: make(map[string][]string),
+	}
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.Func:
+			if obj.Exported() && returnsError(obj) {
+				var fact facts.FunctionErrorsFact
+				pass.ImportObjectFact(obj, &fact)
+				s.Functions[name] = errorKeys(fact.Errors)
+			}
+		case *types.TypeName:
+			if !obj.Exported() || obj.IsAlias() {
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			if iface, ok := named.Underlying().(*types.Interface); ok {
+				for i := 0; i < iface.NumMethods(); i++ {
+					method := iface.Method(i)
+					if method.Exported() && returnsError(method) {
+						var fact facts.InterfaceMethodFact
+						pass.ImportObjectFact(method, &fact)
+						s.Functions[name+"."+method.Name()] = errorKeys(fact.Errors)
+					}
+				}
+				continue
+			}
+			for i := 0; i < named.NumMethods(); i++ {
+				method := named.Method(i)
+				if method.Exported() && returnsError(method) {
+					var fact facts.FunctionErrorsFact
+					pass.ImportObjectFact(method, &fact)
+					s.Functions[name+"."+method.Name()] = errorKeys(fact.Errors)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// returnsError checks if fn has at least one result of type error.
+func returnsError(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && len(internal.FindErrorReturnPositions(sig)) > 0
+}
+
+// errorKeys returns the sorted keys of infos. The result is never nil so that
+// an empty error set is written as [] rather than null.
+func errorKeys(infos []facts.ErrorInfo) []string {
+	keys := make([]string, 0, len(infos))
+	for _, info := range infos {
+		keys = append(keys, info.Key())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Diff compares the current snapshot against a baseline.
+// Functions that exist in only one of the snapshots are skipped: adding or
+// removing a function is an API change the compiler already reports to callers.
+// The result is sorted by function name.
+func Diff(baseline, current *Snapshot) []Change {
+	var changes []Change
+	for name, oldKeys := range baseline.Functions {
+		newKeys, ok := current.Functions[name]
+		if !ok {
+			continue
+		}
+		c := Change{
+			Function: name,
+			Added:    subtract(newKeys, oldKeys),
+			Removed:  subtract(oldKeys, newKeys),
+		}
+		if len(c.Added) > 0 || len(c.Removed) > 0 {
+			changes = append(changes, c)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Function < changes[j].Function
+	})
+	return changes
+}
+
+// subtract returns the keys of a that are not in b, preserving the order of a.
+func subtract(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, key := range b {
+		in[key] = true
+	}
+	var result []string
+	for _, key := range a {
+		if !in[key] {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// Lookup finds the object a snapshot function name refers to in pkg.
+// It returns nil if the function no longer exists.
+func Lookup(pkg *types.Package, name string) types.Object {
+	typeName, methodName, isMethod := strings.Cut(name, ".")
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil || !isMethod {
+		return obj
+	}
+	method, _, _ := types.LookupFieldOrMethod(obj.Type(), true, pkg, methodName)
+	return method
+}
+
+// Write stores the snapshot in dir, replacing any previous snapshot of the package.
+func Write(dir string, s *Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, internal.PackageFileName(s.Package)), append(data, '\n'), 0o644)
+}
+
+// Read loads the snapshot of pkgPath from dir.
+// It returns nil without an error if the package has no snapshot.
+func Read(dir, pkgPath string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(dir, internal.PackageFileName(pkgPath)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,53 @@
+package errset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	baseline := &Snapshot{Functions: map[string][]string{
+		"Find":      {"p.ErrNotFound"},
+		"Delete":    {"p.ErrGone", "p.ErrNotFound"},
+		"Unchanged": {"p.ErrNotFound"},
+		"Removed":   {"p.ErrNotFound"},
+		"Emptied":   {"p.ErrNotFound"},
+	}}
+	current := &Snapshot{Functions: map[string][]string{
+		"Find":      {"p.ErrNotFound", "p.ErrTimeout"},
+		"Delete":    {"p.ErrNotFound"},
+		"Unchanged": {"p.ErrNotFound"},
+		"Added":     {"p.ErrNotFound"},
+		"Emptied":   {},
+	}}
+
+	want := []Change{
+		{Function: "Delete", Removed: []string{"p.ErrGone"}},
+		{Function: "Emptied", Removed: []string{"p.ErrNotFound"}},
+		{Function: "Find", Added: []string{"p.ErrTimeout"}},
+	}
+	if got := Diff(baseline, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	dir := t.TempDir()
+	s := &Snapshot{Package: "example.com/a/b", Functions: map[string][]string{"Find": {}}}
+	if err := Write(dir, s); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := Read(dir, "example.com/a/b")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("Read() = %+v, want %+v", got, s)
+	}
+
+	missing, err := Read(dir, "example.com/other")
+	if err != nil || missing != nil {
+		t.Errorf("Read() of missing package = %+v, %v, want nil, nil", missing, err)
+	}
+}
@@ -1,6 +1,10 @@
 package facts
 
-import "encoding/gob"
+import (
+	"encoding/gob"
+	"strconv"
+	"strings"
+)
 
 func init() {
 	gob.Register(&ErrorFact{})
@@ -9,6 +13,15 @@ func init() {
 	gob.Register(&InterfaceMethodFact{})
 	gob.Register(&FunctionParamCallFlowFact{})
 	gob.Register(&ParameterCheckedErrorsFact{})
+	gob.Register(&CheckFunctionFact{})
+	gob.Register(&UnwrapFact{})
+	gob.Register(&ImplementsFact{})
+	gob.Register(&MockFact{})
+	gob.Register(&ErrorContractFact{})
+	gob.Register(&ErrorParentFact{})
+	gob.Register(&IteratorErrorsFact{})
+	gob.Register(&NilableResultsFact{})
+	gob.Register(&FuncResultErrorsFact{})
 }
 
 // ErrorFact marks a variable or type as an error.
@@ -30,11 +43,38 @@ func (f *ErrorFact) Key() string {
 	return f.PkgPath + "." + f.Name
 }
 
+// ErrorParentFact records the sentinels a hierarchical sentinel wraps, e.g.
+// var ErrUserNotFound = fmt.Errorf("user: %w", ErrNotFound).
+// errors.Is(err, ErrNotFound) is true for such an error, so a check of any ancestor
+// also covers it.
+// Attached to *types.Var objects of sentinels defined by wrapping another sentinel.
+type ErrorParentFact struct {
+	Ancestors []string // Keys of the wrapped sentinels, parents first, then their ancestors
+}
+
+func (*ErrorParentFact) AFact() {}
+
+func (f *ErrorParentFact) String() string {
+	return "wraps:[" + strings.Join(f.Ancestors, ",") + "]"
+}
+
+// ErrorForm is a set of the forms a custom error type is returned in.
+// errors.As and type switches only match the form the error was created in.
+type ErrorForm uint8
+
+const (
+	FormPointer ErrorForm = 1 << iota // Returned as a pointer (&T{})
+	FormValue                         // Returned as a value (T{})
+)
+
 // ErrorInfo contains metadata about an error that a function can return.
 type ErrorInfo struct {
-	PkgPath string // Package path where error is defined
-	Name    string // Variable or type name
-	Wrapped bool   // Whether this error might be wrapped with fmt.Errorf %w
+	PkgPath     string    // Package path where error is defined
+	Name        string    // Variable or type name
+	Wrapped     bool      // Whether this error might be wrapped with fmt.Errorf %w
+	WrappedBy   string    // Key of the wrapper type whose Unwrap returns this error (empty if returned directly)
+	CauseHidden bool      // Whether a wrapper without a Cause method (fmt.Errorf %w) might wrap this error, hiding it from pkg/errors.Cause
+	Forms       ErrorForm // Forms a custom error type is returned in (0 if not known)
 }
 
 func (s ErrorInfo) Key() string {
@@ -65,10 +105,18 @@ func (f *FunctionErrorsFact) String() string {
 }
 
 // AddError adds an error to the fact if not already present.
+// An error that is also returned directly takes precedence over one reached only
+// through a wrapper type, so WrappedBy is cleared in that case. An error hidden from
+// pkg/errors.Cause on any path stays hidden.
 func (f *FunctionErrorsFact) AddError(info ErrorInfo) {
 	key := info.Key()
-	for _, existing := range f.Errors {
-		if existing.Key() == key {
+	for i := range f.Errors {
+		if f.Errors[i].Key() == key {
+			if info.WrappedBy == "" {
+				f.Errors[i].WrappedBy = ""
+			}
+			f.Errors[i].CauseHidden = f.Errors[i].CauseHidden || info.CauseHidden
+			f.Errors[i].Forms |= info.Forms
 			return
 		}
 	}
@@ -96,8 +144,10 @@ func (f *FunctionErrorsFact) FilterByValidErrors(validErrors map[string]bool) {
 
 // ParameterFlowInfo describes how a function parameter flows to return values.
 type ParameterFlowInfo struct {
-	ParamIndex int  // Index of the parameter (0-based, excluding receiver for methods)
-	Wrapped    bool // Whether the parameter is wrapped (e.g., via fmt.Errorf %w)
+	ParamIndex  int    // Index of the parameter (0-based, excluding receiver for methods)
+	Wrapped     bool   // Whether the parameter is wrapped (e.g., via fmt.Errorf %w)
+	WrappedBy   string // Key of the wrapper type whose Unwrap returns the parameter (empty if returned directly)
+	CauseHidden bool   // Whether a wrapper without a Cause method wraps the parameter
 }
 
 // ParameterFlowFact stores information about parameters that flow to return values.
@@ -134,6 +184,12 @@ func (f *ParameterFlowFact) AddFlow(flow ParameterFlowInfo) {
 			if flow.Wrapped && !f.Flows[i].Wrapped {
 				f.Flows[i].Wrapped = true
 			}
+			if flow.WrappedBy == "" {
+				f.Flows[i].WrappedBy = ""
+			}
+			if flow.CauseHidden {
+				f.Flows[i].CauseHidden = true
+			}
 			return
 		}
 	}
@@ -183,8 +239,9 @@ func (f *InterfaceMethodFact) String() string {
 // AddError adds an error to the fact if not already present.
 func (f *InterfaceMethodFact) AddError(info ErrorInfo) {
 	key := info.Key()
-	for _, existing := range f.Errors {
-		if existing.Key() == key {
+	for i := range f.Errors {
+		if f.Errors[i].Key() == key {
+			f.Errors[i].Forms |= info.Forms
 			return
 		}
 	}
@@ -201,14 +258,21 @@ func (f *InterfaceMethodFact) AddErrors(infos []ErrorInfo) {
 // FunctionParamCallFlowInfo describes how a function parameter's call result
 // flows to the return value.
 type FunctionParamCallFlowInfo struct {
-	ParamIndex int  // Index of the function-typed parameter (0-based)
-	Wrapped    bool // Whether the result is wrapped (e.g., via fmt.Errorf %w)
+	ParamIndex  int    // Index of the function-typed parameter (0-based)
+	Wrapped     bool   // Whether the result is wrapped (e.g., via fmt.Errorf %w)
+	CauseHidden bool   // Whether a wrapper without a Cause method wraps the result
+	Elements    bool   // Whether every element of a variadic or slice parameter is called
+	Method      string // Method called on a parameter of type parameter type, instead of calling the parameter
 }
 
 // FunctionParamCallFlowFact tracks parameters that are functions whose
 // call results flow to the return value.
 // Example: func RunInTx(fn func() error) error { return fn() }
 // -> FunctionParamCallFlowFact{CallFlows: [{ParamIndex: 0}]}
+// Example: func RunAll(fns ...func() error) error { for _, fn := range fns { ... fn() ... } }
+// -> FunctionParamCallFlowFact{CallFlows: [{ParamIndex: 0, Elements: true}]}
+// Example: func Fetch[R Repository](r R, id int) error { return r.Get(id) }
+// -> FunctionParamCallFlowFact{CallFlows: [{ParamIndex: 0, Method: "Get"}]}
 // Attached to *types.Func objects.
 type FunctionParamCallFlowFact struct {
 	CallFlows []FunctionParamCallFlowInfo
@@ -228,8 +292,14 @@ func (f *FunctionParamCallFlowFact) String() string {
 		if flow.Wrapped {
 			result += "wrapped:"
 		}
+		if flow.Elements {
+			result += "each:"
+		}
 		result += "call:"
 		result += string(rune('0' + flow.ParamIndex))
+		if flow.Method != "" {
+			result += "." + flow.Method
+		}
 	}
 	result += "]"
 	return result
@@ -238,11 +308,17 @@ func (f *FunctionParamCallFlowFact) String() string {
 // AddCallFlow adds a function parameter call flow to the fact if not already present.
 func (f *FunctionParamCallFlowFact) AddCallFlow(flow FunctionParamCallFlowInfo) {
 	for i, existing := range f.CallFlows {
-		if existing.ParamIndex == flow.ParamIndex {
+		if existing.ParamIndex == flow.ParamIndex && existing.Method == flow.Method {
 			// If already exists, upgrade to wrapped if needed
 			if flow.Wrapped && !existing.Wrapped {
 				f.CallFlows[i].Wrapped = true
 			}
+			if flow.CauseHidden && !existing.CauseHidden {
+				f.CallFlows[i].CauseHidden = true
+			}
+			if flow.Elements && !existing.Elements {
+				f.CallFlows[i].Elements = true
+			}
 			return
 		}
 	}
@@ -259,6 +335,16 @@ func (f *FunctionParamCallFlowFact) HasCallFlowForParam(paramIndex int) bool {
 	return false
 }
 
+// hasCallFlow checks if a call flow exists for the given parameter index and method.
+func (f *FunctionParamCallFlowFact) hasCallFlow(paramIndex int, method string) bool {
+	for _, flow := range f.CallFlows {
+		if flow.ParamIndex == paramIndex && flow.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
 // Merge merges another fact's flows into this one.
 func (f *FunctionParamCallFlowFact) Merge(other *FunctionParamCallFlowFact) {
 	for _, flow := range other.CallFlows {
@@ -335,6 +421,279 @@ func (f *ParameterCheckedErrorsFact) GetCheckedErrors(paramIndex int) []ErrorInf
 	return nil
 }
 
+// CheckFunctionFact marks a function as an error-checking helper.
+// A call such as apperr.IsNotFound(err) is treated like errors.Is(err, ErrNotFound)
+// for every error in Errors. A helper returning a code, such as status.Code(err),
+// checks the errors of a value it is compared with: Code(err) == codes.NotFound.
+// Attached to *types.Func objects annotated with //goexhauerrors:checks.
+type CheckFunctionFact struct {
+	ParamIndex int          // Index of the checked error parameter (0-based, excluding receiver)
+	Errors     []ErrorInfo  // Errors covered by a call to this helper
+	Values     []CheckValue // Errors covered by comparing the result with a constant
+}
+
+// CheckValue is a constant a code helper's result is compared with and the errors
+// the comparison covers.
+type CheckValue struct {
+	Value  string      // Fully qualified constant (e.g., "example.com/apperr.CodeNotFound")
+	Errors []ErrorInfo // Errors covered by Code(err) == Value
+}
+
+func (*CheckFunctionFact) AFact() {}
+
+func (f *CheckFunctionFact) String() string {
+	result := "checks:param" + string(rune('0'+f.ParamIndex)) + ":["
+	for i, err := range f.Errors {
+		if i > 0 {
+			result += ","
+		}
+		result += err.Key()
+	}
+	result += "]"
+	for _, v := range f.Values {
+		result += " " + v.Value + "=["
+		for i, err := range v.Errors {
+			if i > 0 {
+				result += ","
+			}
+			result += err.Key()
+		}
+		result += "]"
+	}
+	return result
+}
+
+// AddError adds a covered error to the fact if not already present.
+func (f *CheckFunctionFact) AddError(info ErrorInfo) {
+	if ContainsErrorInfo(f.Errors, info) {
+		return
+	}
+	f.Errors = append(f.Errors, info)
+}
+
+// AddValueError adds an error covered by comparing the result with value.
+func (f *CheckFunctionFact) AddValueError(value string, info ErrorInfo) {
+	for i := range f.Values {
+		if f.Values[i].Value == value {
+			if !ContainsErrorInfo(f.Values[i].Errors, info) {
+				f.Values[i].Errors = append(f.Values[i].Errors, info)
+			}
+			return
+		}
+	}
+	f.Values = append(f.Values, CheckValue{Value: value, Errors: []ErrorInfo{info}})
+}
+
+// ValueErrors returns the errors covered by comparing the result with value.
+func (f *CheckFunctionFact) ValueErrors(value string) []ErrorInfo {
+	for _, v := range f.Values {
+		if v.Value == value {
+			return v.Errors
+		}
+	}
+	return nil
+}
+
+// UnwrapFact records which struct fields an error type's Unwrap method returns.
+// A composite literal of the type carries the errors stored in these fields,
+// so callers can match them with errors.Is through the wrapper.
+// Attached to *types.TypeName objects of types with Unwrap() error or Unwrap() []error.
+type UnwrapFact struct {
+	Fields []string // Names of the fields returned by Unwrap
+}
+
+func (*UnwrapFact) AFact() {}
+
+func (f *UnwrapFact) String() string {
+	return "unwraps:" + strings.Join(f.Fields, ",")
+}
+
+// HasField checks if the named field is returned by Unwrap.
+func (f *UnwrapFact) HasField(name string) bool {
+	for _, field := range f.Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ImplementsFact records the imported interfaces a concrete type implements.
+// Facts propagate along the import graph, so a package that transitively imports
+// the implementation can find it without depending on the order packages are analyzed in.
+// Attached to *types.TypeName objects of types implementing interfaces of other packages.
+type ImplementsFact struct {
+	Interfaces []string // Keys of the implemented interfaces ("pkgPath.TypeName")
+}
+
+func (*ImplementsFact) AFact() {}
+
+func (f *ImplementsFact) String() string {
+	return "implements:[" + strings.Join(f.Interfaces, ", ") + "]"
+}
+
+// Implements checks if the type implements the interface with the given key.
+func (f *ImplementsFact) Implements(ifaceKey string) bool {
+	for _, key := range f.Interfaces {
+		if key == ifaceKey {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorContractFact declares the errors an interface method may return.
+// Callers use the contract instead of the union of the implementations, and every
+// implementation is verified against it.
+// Attached to *types.Func objects of interface methods annotated with //goexhauerrors:returns.
+type ErrorContractFact struct {
+	Errors []ErrorInfo // Errors declared by the contract
+}
+
+func (*ErrorContractFact) AFact() {}
+
+func (f *ErrorContractFact) String() string {
+	result := "returns:["
+	for i, err := range f.Errors {
+		if i > 0 {
+			result += ","
+		}
+		result += err.Key()
+	}
+	result += "]"
+	return result
+}
+
+// AddError adds a declared error to the contract if not already present.
+func (f *ErrorContractFact) AddError(info ErrorInfo) {
+	if ContainsErrorInfo(f.Errors, info) {
+		return
+	}
+	f.Errors = append(f.Errors, info)
+}
+
+// IteratorErrorsFact stores the errors a range-over-func iterator yields, i.e. the errors
+// passed to yield (e.g., the second argument of yield in an iter.Seq2[T, error]).
+// Attached to *types.Func objects of functions returning such an iterator, and of
+// functions that are such an iterator themselves.
+type IteratorErrorsFact struct {
+	Errors []ErrorInfo
+}
+
+func (*IteratorErrorsFact) AFact() {}
+
+func (f *IteratorErrorsFact) String() string {
+	result := "yields:["
+	for i, err := range f.Errors {
+		if i > 0 {
+			result += ", "
+		}
+		result += err.Key()
+	}
+	result += "]"
+	return result
+}
+
+// AddError adds a yielded error to the fact if not already present.
+func (f *IteratorErrorsFact) AddError(info ErrorInfo) {
+	key := info.Key()
+	for i := range f.Errors {
+		if f.Errors[i].Key() == key {
+			f.Errors[i].Forms |= info.Forms
+			return
+		}
+	}
+	f.Errors = append(f.Errors, info)
+}
+
+// FilterByValidErrors removes errors that are not in the provided set of valid errors.
+func (f *IteratorErrorsFact) FilterByValidErrors(validErrors map[string]bool) {
+	var filtered []ErrorInfo
+	for _, s := range f.Errors {
+		if validErrors[s.Key()] {
+			filtered = append(filtered, s)
+		}
+	}
+	f.Errors = filtered
+}
+
+// FuncResultErrorsFact stores the errors of calling the function value a function returns,
+// such as the functional option built by func WithPort(port int) Option.
+// Attached to *types.Func objects of functions with a function-typed result.
+type FuncResultErrorsFact struct {
+	Errors []ErrorInfo
+}
+
+func (*FuncResultErrorsFact) AFact() {}
+
+func (f *FuncResultErrorsFact) String() string {
+	result := "returnsFunc:["
+	for i, err := range f.Errors {
+		if i > 0 {
+			result += ", "
+		}
+		result += err.Key()
+	}
+	result += "]"
+	return result
+}
+
+// AddError adds an error of the returned function to the fact if not already present.
+func (f *FuncResultErrorsFact) AddError(info ErrorInfo) {
+	if ContainsErrorInfo(f.Errors, info) {
+		return
+	}
+	f.Errors = append(f.Errors, info)
+}
+
+// NilableResultsFact records the custom error pointer results (*MyError) a function
+// returns nil in on some path. Such a function fails with the error, while one that
+// never returns nil (func NewMyError() *MyError) is a constructor of the error.
+// Attached to *types.Func objects of functions with a FunctionErrorsFact.
+type NilableResultsFact struct {
+	Results []int // Indices of the results that may be nil
+}
+
+func (*NilableResultsFact) AFact() {}
+
+func (f *NilableResultsFact) String() string {
+	result := "nilable:["
+	for i, index := range f.Results {
+		if i > 0 {
+			result += ", "
+		}
+		result += strconv.Itoa(index)
+	}
+	result += "]"
+	return result
+}
+
+// Nilable checks if the result at index may be nil.
+func (f *NilableResultsFact) Nilable(index int) bool {
+	for _, i := range f.Results {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// MockFact marks a type as a test double. Mocks implement interfaces only to return
+// whatever errors tests need, so they are excluded from the error sets of those interfaces.
+// Attached to *types.TypeName objects of types annotated with //goexhauerrors:mock.
+type MockFact struct{}
+
+func (*MockFact) AFact() {}
+
+func (*MockFact) String() string {
+	return "mock"
+}
+
+// InterfaceMethodKey builds a key identifying an interface method from package path, type name, and method name.
+func InterfaceMethodKey(pkgPath, typeName, methodName string) string {
+	return pkgPath + "." + typeName + "." + methodName
+}
+
 // IntersectParameterFlowFacts computes the intersection of ParameterFlowFact across implementations.
 // A parameter flow is kept only if it exists in ALL non-nil facts.
 // If any fact is nil (implementation has no flow), the intersection is empty.
@@ -430,7 +789,7 @@ func IntersectFunctionParamCallFlowFacts(facts []*FunctionParamCallFlowFact) *Fu
 	for _, flow := range facts[0].CallFlows {
 		presentInAll := true
 		for _, other := range facts[1:] {
-			if !other.HasCallFlowForParam(flow.ParamIndex) {
+			if !other.hasCallFlow(flow.ParamIndex, flow.Method) {
 				presentInAll = false
 				break
 			}
@@ -108,6 +108,34 @@ func TestFunctionErrorsFact_AddError(t *testing.T) {
 		}
 	})
 
+	t.Run("direct error clears wrapper", func(t *testing.T) {
+		f := &FunctionErrorsFact{}
+		f.AddError(ErrorInfo{PkgPath: "p", Name: "A", WrappedBy: "p.OpError"})
+		f.AddError(ei("p", "A"))
+		if len(f.Errors) != 1 || f.Errors[0].WrappedBy != "" {
+			t.Fatalf("expected a single direct error, got %+v", f.Errors)
+		}
+	})
+
+	t.Run("wrapper does not override direct error", func(t *testing.T) {
+		f := &FunctionErrorsFact{}
+		f.AddError(ei("p", "A"))
+		f.AddError(ErrorInfo{PkgPath: "p", Name: "A", WrappedBy: "p.OpError"})
+		if len(f.Errors) != 1 || f.Errors[0].WrappedBy != "" {
+			t.Fatalf("expected a single direct error, got %+v", f.Errors)
+		}
+	})
+
+	t.Run("forms are merged", func(t *testing.T) {
+		f := &FunctionErrorsFact{}
+		f.AddError(ErrorInfo{PkgPath: "p", Name: "E", Forms: FormPointer})
+		f.AddError(ei("p", "E"))
+		f.AddError(ErrorInfo{PkgPath: "p", Name: "E", Forms: FormValue})
+		if len(f.Errors) != 1 || f.Errors[0].Forms != FormPointer|FormValue {
+			t.Fatalf("expected a single error returned in both forms, got %+v", f.Errors)
+		}
+	})
+
 	t.Run("empty fact", func(t *testing.T) {
 		f := &FunctionErrorsFact{}
 		if len(f.Errors) != 0 {
@@ -427,6 +455,15 @@ func TestFunctionParamCallFlowFact_AddCallFlow(t *testing.T) {
 			t.Error("expected Wrapped=true, should not downgrade")
 		}
 	})
+
+	t.Run("upgrade elements", func(t *testing.T) {
+		f := &FunctionParamCallFlowFact{}
+		f.AddCallFlow(FunctionParamCallFlowInfo{ParamIndex: 0})
+		f.AddCallFlow(FunctionParamCallFlowInfo{ParamIndex: 0, Elements: true})
+		if !f.CallFlows[0].Elements {
+			t.Error("expected Elements=true after upgrade")
+		}
+	})
 }
 
 func TestFunctionParamCallFlowFact_Merge(t *testing.T) {
@@ -462,6 +499,9 @@ func TestFunctionParamCallFlowFact_String(t *testing.T) {
 		{"single", []FunctionParamCallFlowInfo{{ParamIndex: 0}}, "[call:0]"},
 		{"wrapped", []FunctionParamCallFlowInfo{{ParamIndex: 1, Wrapped: true}}, "[wrapped:call:1]"},
 		{"multiple", []FunctionParamCallFlowInfo{{ParamIndex: 0}, {ParamIndex: 2, Wrapped: true}}, "[call:0, wrapped:call:2]"},
+		{"elements", []FunctionParamCallFlowInfo{{ParamIndex: 0, Elements: true}}, "[each:call:0]"},
+		{"wrapped elements", []FunctionParamCallFlowInfo{{ParamIndex: 1, Wrapped: true, Elements: true}}, "[wrapped:each:call:1]"},
+		{"method", []FunctionParamCallFlowInfo{{ParamIndex: 0, Method: "Get"}}, "[call:0.Get]"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -602,6 +642,187 @@ func TestParameterCheckedErrorsFact_AFact(t *testing.T) {
 	f.AFact()
 }
 
+// ---------------------------------------------------------------------------
+// CheckFunctionFact
+// ---------------------------------------------------------------------------
+
+func TestCheckFunctionFact_AddError(t *testing.T) {
+	f := &CheckFunctionFact{}
+	f.AddError(ei("p", "A"))
+	f.AddError(ei("p", "B"))
+	f.AddError(eiw("p", "A")) // same key
+	if len(f.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(f.Errors))
+	}
+}
+
+func TestCheckFunctionFact_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fact CheckFunctionFact
+		want string
+	}{
+		{"empty", CheckFunctionFact{}, "checks:param0:[]"},
+		{"single error", CheckFunctionFact{Errors: []ErrorInfo{ei("p", "A")}}, "checks:param0:[p.A]"},
+		{"second param", CheckFunctionFact{ParamIndex: 1, Errors: []ErrorInfo{ei("p", "A"), ei("q", "B")}}, "checks:param1:[p.A,q.B]"},
+		{"values", CheckFunctionFact{Values: []CheckValue{{Value: "p.CodeA", Errors: []ErrorInfo{ei("p", "A"), ei("q", "B")}}}}, "checks:param0:[] p.CodeA=[p.A,q.B]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fact.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFunctionFact_AddValueError(t *testing.T) {
+	f := &CheckFunctionFact{}
+	f.AddValueError("p.CodeA", ei("p", "A"))
+	f.AddValueError("p.CodeA", ei("p", "B"))
+	f.AddValueError("p.CodeA", eiw("p", "A")) // same key
+	f.AddValueError("p.CodeC", ei("p", "C"))
+	if len(f.Values) != 2 {
+		t.Fatalf("expected 2 values, got %d", len(f.Values))
+	}
+	if got := f.ValueErrors("p.CodeA"); len(got) != 2 {
+		t.Errorf("ValueErrors(p.CodeA) returned %d errors, want 2", len(got))
+	}
+	if got := f.ValueErrors("p.CodeX"); got != nil {
+		t.Errorf("ValueErrors(p.CodeX) = %v, want nil", got)
+	}
+}
+
+func TestCheckFunctionFact_AFact(t *testing.T) {
+	f := &CheckFunctionFact{}
+	f.AFact()
+}
+
+// ---------------------------------------------------------------------------
+// UnwrapFact
+// ---------------------------------------------------------------------------
+
+func TestUnwrapFact_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fact UnwrapFact
+		want string
+	}{
+		{"single field", UnwrapFact{Fields: []string{"Err"}}, "unwraps:Err"},
+		{"multiple fields", UnwrapFact{Fields: []string{"Primary", "Secondary"}}, "unwraps:Primary,Secondary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fact.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnwrapFact_HasField(t *testing.T) {
+	f := &UnwrapFact{Fields: []string{"Err", "Cause"}}
+	if !f.HasField("Cause") {
+		t.Error("expected HasField(Cause) = true")
+	}
+	if f.HasField("Op") {
+		t.Error("expected HasField(Op) = false")
+	}
+}
+
+func TestUnwrapFact_AFact(t *testing.T) {
+	f := &UnwrapFact{}
+	f.AFact()
+}
+
+// ---------------------------------------------------------------------------
+// FuncResultErrorsFact
+// ---------------------------------------------------------------------------
+
+func TestFuncResultErrorsFact_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fact FuncResultErrorsFact
+		want string
+	}{
+		{"empty", FuncResultErrorsFact{}, "returnsFunc:[]"},
+		{"multiple errors", FuncResultErrorsFact{Errors: []ErrorInfo{ei("p", "A"), ei("q", "B")}}, "returnsFunc:[p.A, q.B]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fact.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncResultErrorsFact_AddError(t *testing.T) {
+	f := &FuncResultErrorsFact{}
+	f.AddError(ei("p", "A"))
+	f.AddError(ei("p", "A"))
+	if len(f.Errors) != 1 {
+		t.Errorf("len(Errors) = %d, want 1", len(f.Errors))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NilableResultsFact
+// ---------------------------------------------------------------------------
+
+func TestNilableResultsFact_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fact NilableResultsFact
+		want string
+	}{
+		{"single result", NilableResultsFact{Results: []int{0}}, "nilable:[0]"},
+		{"multiple results", NilableResultsFact{Results: []int{0, 2}}, "nilable:[0, 2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fact.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilableResultsFact_Nilable(t *testing.T) {
+	f := &NilableResultsFact{Results: []int{1}}
+	if !f.Nilable(1) {
+		t.Error("expected Nilable(1) = true")
+	}
+	if f.Nilable(0) {
+		t.Error("expected Nilable(0) = false")
+	}
+}
+
+func TestErrorParentFact_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fact ErrorParentFact
+		want string
+	}{
+		{"parent", ErrorParentFact{Ancestors: []string{"pkg.ErrNotFound"}}, "wraps:[pkg.ErrNotFound]"},
+		{"ancestors", ErrorParentFact{Ancestors: []string{"pkg.ErrUserNotFound", "pkg.ErrNotFound"}}, "wraps:[pkg.ErrUserNotFound,pkg.ErrNotFound]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fact.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIteratorErrorsFact_String(t *testing.T) {
+	fact := IteratorErrorsFact{Errors: []ErrorInfo{ei("pkg", "ErrClosed"), ei("pkg", "ErrDecode")}}
+	if got, want := fact.String(), "yields:[pkg.ErrClosed, pkg.ErrDecode]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // IntersectParameterFlowFacts
 // ---------------------------------------------------------------------------
@@ -0,0 +1,169 @@
+// Package implindex stores the errors of interface implementations in files,
+// so that callers can resolve interface methods whose implementations live in
+// packages they do not import (the DI pattern).
+//
+// Analysis facts only flow along the import graph. An implementation package that
+// the caller never imports is invisible to it, whatever the driver or analysis order.
+// The index makes that information an explicit input instead: a first run with
+// -writeImplIndex records the implementations of every package, and a second run
+// with -implIndex reads them back. Both runs are deterministic.
+package implindex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+)
+
+// Entry describes the implementations of one interface method found in one package.
+type Entry struct {
+	Errors    []facts.ErrorInfo                 `json:"errors,omitempty"`    // Union of errors of the implementations
+	CallFlows []facts.FunctionParamCallFlowInfo `json:"callFlows,omitempty"` // Intersection of call flows of the implementations
+}
+
+// Index maps interface method keys ("pkgPath.TypeName.Method") to entries.
+type Index map[string]*Entry
+
+// loadedIndex is an index directory as it was loaded.
+type loadedIndex struct {
+	stamp   string              // names, sizes and modification times of the files read
+	entries map[string][]*Entry // entries by interface method key
+}
+
+var (
+	writeDir string
+	readDir  string
+	loaded   map[string]*loadedIndex // cache of loaded index directories
+	mu       sync.Mutex
+)
+
+// SetWriteDir sets the directory the implementations of each analyzed package are written to.
+// An empty string disables writing.
+func SetWriteDir(dir string) {
+	mu.Lock()
+	defer mu.Unlock()
+	writeDir = dir
+}
+
+// SetReadDir sets the directory of a previously written index to resolve interface methods from.
+// An empty string disables the index.
+func SetReadDir(dir string) {
+	mu.Lock()
+	defer mu.Unlock()
+	readDir = dir
+}
+
+// Write stores the implementations found in pkgPath, if writing is enabled.
+// A package without implementations removes its stale file, if any.
+func Write(pkgPath string, idx Index) error {
+	mu.Lock()
+	dir := writeDir
+	mu.Unlock()
+	if dir == "" {
+		return nil
+	}
+
+	path := filepath.Join(dir, internal.PackageFileName(pkgPath))
+	if len(idx) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Load reads the index directory set with SetReadDir, unless it is already loaded
+// and none of its files has changed since. A driver analyzing several times in one
+// process thus sees an index rewritten in between.
+// It is a no-op if the index is disabled.
+func Load() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if readDir == "" {
+		return nil
+	}
+	files, st, err := stamp(readDir)
+	if err != nil {
+		return err
+	}
+	if cached, ok := loaded[readDir]; ok && cached.stamp == st {
+		return nil
+	}
+
+	entries, err := load(files)
+	if err != nil {
+		return err
+	}
+	if loaded == nil {
+		loaded = make(map[string]*loadedIndex)
+	}
+	loaded[readDir] = &loadedIndex{stamp: st, entries: entries}
+	return nil
+}
+
+// Lookup returns the entries recorded for an interface method, one per implementation
+// package, ordered by package path. It returns nil if the index is disabled, not loaded,
+// or has no entry for the method.
+func Lookup(key string) []*Entry {
+	mu.Lock()
+	defer mu.Unlock()
+	cached, ok := loaded[readDir]
+	if !ok {
+		return nil
+	}
+	return cached.entries[key]
+}
+
+// stamp returns the package files of dir, in package path order, and a string
+// identifying their current contents by name, size and modification time.
+func stamp(dir string) ([]string, string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, "", err
+	}
+	var b strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", filepath.Base(file), info.Size(), info.ModTime().UnixNano())
+	}
+	return files, b.String(), nil
+}
+
+// load reads the package files and groups the entries by interface method key.
+// Files are read in package path order, so the grouping is deterministic.
+func load(files []string) (map[string][]*Entry, error) {
+	entries := make(map[string][]*Entry)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var idx Index
+		if err := json.Unmarshal(data, &idx); err != nil {
+			return nil, err
+		}
+		for key, entry := range idx {
+			entries[key] = append(entries[key], entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,84 @@
+package implindex
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+)
+
+func TestWriteLookup(t *testing.T) {
+	dir := t.TempDir()
+	const key = "example.com/domain.Repository.Find"
+
+	SetWriteDir(dir)
+	defer SetWriteDir("")
+	infraA := &Entry{Errors: []facts.ErrorInfo{{PkgPath: "example.com/domain", Name: "ErrNotFound"}}}
+	infraB := &Entry{CallFlows: []facts.FunctionParamCallFlowInfo{{ParamIndex: 0}}}
+	if err := Write("example.com/infra/b", Index{key: infraB}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := Write("example.com/infra/a", Index{key: infraA}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	// A package without implementations writes nothing
+	if err := Write("example.com/other", nil); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	SetReadDir(dir)
+	defer SetReadDir("")
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Entries are ordered by implementation package path
+	want := []*Entry{infraA, infraB}
+	if got := Lookup(key); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lookup(%q) = %+v, want %+v", key, got, want)
+	}
+	if got := Lookup("example.com/domain.Repository.Save"); got != nil {
+		t.Errorf("Lookup() of unknown method = %+v, want nil", got)
+	}
+}
+
+func TestLoadReloadsChangedIndex(t *testing.T) {
+	dir := t.TempDir()
+	const key = "example.com/domain.Repository.Find"
+
+	SetWriteDir(dir)
+	defer SetWriteDir("")
+	first := &Entry{Errors: []facts.ErrorInfo{{PkgPath: "example.com/domain", Name: "ErrNotFound"}}}
+	if err := Write("example.com/infra", Index{key: first}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	SetReadDir(dir)
+	defer SetReadDir("")
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := Lookup(key); !reflect.DeepEqual(got, []*Entry{first}) {
+		t.Fatalf("Lookup(%q) = %+v, want %+v", key, got, []*Entry{first})
+	}
+
+	// Rewrite the file in place, as a later -writeImplIndex run does
+	second := &Entry{Errors: []facts.ErrorInfo{{PkgPath: "example.com/domain", Name: "ErrConflict"}}}
+	if err := Write("example.com/infra", Index{key: second}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, internal.PackageFileName("example.com/infra")), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := Lookup(key); !reflect.DeepEqual(got, []*Entry{second}) {
+		t.Errorf("Lookup(%q) after rewrite = %+v, want %+v", key, got, []*Entry{second})
+	}
+}
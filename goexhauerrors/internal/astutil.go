@@ -26,6 +26,29 @@ func IsErrorType(t types.Type) bool {
 	return false
 }
 
+// IsErrorResultType checks if a result or variable of the given type carries errors:
+// the error interface, interfaces embedding it (apperr.Error), and pointers to custom
+// error types (*MyError).
+func IsErrorResultType(t types.Type) bool {
+	if IsErrorType(t) {
+		return true
+	}
+	if _, ok := types.Unalias(t).(*types.TypeParam); ok {
+		return false
+	}
+	errorInterface := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		return types.Implements(t, errorInterface)
+	case *types.Pointer:
+		if _, ok := u.Elem().Underlying().(*types.Interface); ok {
+			return false
+		}
+		return types.Implements(t, errorInterface)
+	}
+	return false
+}
+
 // ExtractStringLiteral extracts the string value from a basic literal.
 func ExtractStringLiteral(expr ast.Expr) string {
 	lit, ok := expr.(*ast.BasicLit)
@@ -45,18 +68,40 @@ func ExtractStringLiteral(expr ast.Expr) string {
 	return s
 }
 
-// FindErrorReturnPositions finds which return value positions are error type.
+// FindErrorReturnPositions finds which return value positions carry errors (see IsErrorResultType).
 func FindErrorReturnPositions(sig *types.Signature) []int {
 	var positions []int
 	results := sig.Results()
 	for i := 0; i < results.Len(); i++ {
-		if IsErrorType(results.At(i).Type()) {
+		if IsErrorResultType(results.At(i).Type()) {
 			positions = append(positions, i)
 		}
 	}
 	return positions
 }
 
+// IteratorYieldErrorIndex returns the index of the error-typed parameter of yield if t
+// is a range-over-func iterator yielding errors (e.g., iter.Seq2[T, error]), or -1.
+func IteratorYieldErrorIndex(t types.Type) int {
+	sig, ok := t.Underlying().(*types.Signature)
+	if !ok || sig.Params().Len() != 1 || sig.Results().Len() != 0 {
+		return -1
+	}
+	yield, ok := sig.Params().At(0).Type().Underlying().(*types.Signature)
+	if !ok || yield.Results().Len() != 1 {
+		return -1
+	}
+	if basic, ok := yield.Results().At(0).Type().Underlying().(*types.Basic); !ok || basic.Kind() != types.Bool {
+		return -1
+	}
+	for i := 0; i < yield.Params().Len(); i++ {
+		if IsErrorType(yield.Params().At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
+
 // FindErrorParamVars returns a map from *types.Var (parameter) to its index
 // for all error-typed parameters in the signature.
 func FindErrorParamVars(sig *types.Signature) map[*types.Var]int {
@@ -72,10 +117,19 @@ func FindErrorParamVars(sig *types.Signature) map[*types.Var]int {
 }
 
 // GetCalledFunction returns the *types.Func for a call expression if available.
+// Explicitly instantiated generic functions (Load[T](x)) resolve to the generic function.
 func GetCalledFunction(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
 	var obj types.Object
 
-	switch fun := call.Fun.(type) {
+	fun := ast.Unparen(call.Fun)
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+
+	switch fun := fun.(type) {
 	case *ast.Ident:
 		obj = pass.TypesInfo.Uses[fun]
 	case *ast.SelectorExpr:
@@ -90,6 +144,28 @@ func GetCalledFunction(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
 	return nil
 }
 
+// FuncValueVar returns the variable or struct field holding the function value expr denotes:
+// a variable (handler), a field (s.onSave), or an element of either (r.handlers[path]).
+// It returns nil for other expressions, including references to declared functions.
+func FuncValueVar(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj := pass.TypesInfo.Uses[e]
+		if obj == nil {
+			obj = pass.TypesInfo.Defs[e]
+		}
+		v, _ := obj.(*types.Var)
+		return v
+	case *ast.SelectorExpr:
+		if v, ok := pass.TypesInfo.Uses[e.Sel].(*types.Var); ok && v.IsField() {
+			return v
+		}
+	case *ast.IndexExpr:
+		return FuncValueVar(pass, e.X)
+	}
+	return nil
+}
+
 // ExtractCompositeLit extracts composite literal from &MyError{} pattern.
 func ExtractCompositeLit(call *ast.CallExpr) *ast.CompositeLit {
 	// This handles cases like (&MyError{}).SomeMethod()
@@ -117,40 +193,25 @@ func ExtractNamedType(t types.Type) *types.Named {
 	return nil
 }
 
-// IsFmtErrorfCall checks if the call is fmt.Errorf.
-func IsFmtErrorfCall(pass *analysis.Pass, call *ast.CallExpr) bool {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	if sel.Sel.Name != "Errorf" {
-		return false
-	}
-
-	ident, ok := sel.X.(*ast.Ident)
-	if !ok {
-		return false
-	}
-
-	obj := pass.TypesInfo.Uses[ident]
-	pkgName, ok := obj.(*types.PkgName)
-	if !ok {
-		return false
-	}
-
-	return pkgName.Imported().Path() == "fmt"
+// FormatVerb is a formatting directive in a printf-style format string.
+type FormatVerb struct {
+	Offset   int    // Byte offset of the '%' in the format string
+	Text     string // Full directive text including flags (e.g., "%+v")
+	Verb     byte   // Verb character (e.g., 'v', 'w')
+	ArgIndex int    // Index of the consumed argument, counted from the first argument after the format
 }
 
-// FindWrapVerbIndices finds the argument indices for %w verbs in format string.
-func FindWrapVerbIndices(format string) []int {
-	var indices []int
+// FindFormatVerbs parses the formatting directives of a printf-style format string.
+// %% is skipped since it doesn't consume an argument.
+func FindFormatVerbs(format string) []FormatVerb {
+	var verbs []FormatVerb
 	argIndex := 0
 
 	for i := 0; i < len(format); i++ {
 		if format[i] != '%' {
 			continue
 		}
+		start := i
 		if i+1 >= len(format) {
 			break
 		}
@@ -183,12 +244,26 @@ func FindWrapVerbIndices(format string) []int {
 			continue // %% doesn't consume argument
 		}
 
-		if verb == 'w' {
-			indices = append(indices, argIndex)
-		}
+		verbs = append(verbs, FormatVerb{
+			Offset:   start,
+			Text:     format[start : i+1],
+			Verb:     verb,
+			ArgIndex: argIndex,
+		})
 		argIndex++
 	}
 
+	return verbs
+}
+
+// FindWrapVerbIndices finds the argument indices for %w verbs in format string.
+func FindWrapVerbIndices(format string) []int {
+	var indices []int
+	for _, verb := range FindFormatVerbs(format) {
+		if verb.Verb == 'w' {
+			indices = append(indices, verb.ArgIndex)
+		}
+	}
 	return indices
 }
 
@@ -227,39 +302,25 @@ func ExtractErrorInfoFromAsTarget(pass *analysis.Pass, expr ast.Expr) *facts.Err
 	return &facts.ErrorInfo{PkgPath: parts[0], Name: parts[1]}
 }
 
-// IsErrorsIsCall checks if the call is errors.Is().
+// IsErrorsIsCall checks if the call is errors.Is() or a registered equivalent
+// (e.g., github.com/cockroachdb/errors.IsAny).
 func IsErrorsIsCall(pass *analysis.Pass, call *ast.CallExpr) bool {
-	return IsErrorsPkgCall(pass, call, "Is")
+	return isCheckerCall(pass, call, CheckerIs)
 }
 
-// IsErrorsAsCall checks if the call is errors.As().
+// IsErrorsAsCall checks if the call is errors.As() or a registered equivalent.
 func IsErrorsAsCall(pass *analysis.Pass, call *ast.CallExpr) bool {
-	return IsErrorsPkgCall(pass, call, "As")
+	return isCheckerCall(pass, call, CheckerAs)
 }
 
-// IsErrorsPkgCall checks if the call is errors.<funcName>().
-func IsErrorsPkgCall(pass *analysis.Pass, call *ast.CallExpr, funcName string) bool {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	if sel.Sel.Name != funcName {
-		return false
-	}
-
-	ident, ok := sel.X.(*ast.Ident)
-	if !ok {
+// isCheckerCall checks if the call is a known error-chain checker of the given kind.
+func isCheckerCall(pass *analysis.Pass, call *ast.CallExpr, kind CheckerKind) bool {
+	fn := GetCalledFunction(pass, call)
+	if fn == nil {
 		return false
 	}
-
-	obj := pass.TypesInfo.Uses[ident]
-	pkgName, ok := obj.(*types.PkgName)
-	if !ok {
-		return false
-	}
-
-	return pkgName.Imported().Path() == "errors"
+	k, ok := LookupChecker(fn)
+	return ok && k == kind
 }
 
 // ExtractErrorKey extracts the error key from an errors.Is second argument.
@@ -405,26 +466,14 @@ func FindArgIndexForVar(pass *analysis.Pass, call *ast.CallExpr, targetVar *type
 	return -1
 }
 
-// IsFmtErrorfWrappingVariable checks if a fmt.Errorf call wraps the given variable with %w.
-func IsFmtErrorfWrappingVariable(pass *analysis.Pass, call *ast.CallExpr, targetVar *types.Var) bool {
-	if len(call.Args) < 1 {
-		return false
-	}
-
-	formatStr := ExtractStringLiteral(call.Args[0])
-	if formatStr == "" {
-		return false
-	}
-
-	wrapIndices := FindWrapVerbIndices(formatStr)
-	for _, wrapIdx := range wrapIndices {
-		argIdx := 1 + wrapIdx // args[0] is format string
-		if argIdx < len(call.Args) {
-			if ident, ok := call.Args[argIdx].(*ast.Ident); ok {
-				obj := pass.TypesInfo.Uses[ident]
-				if obj == targetVar {
-					return true
-				}
+// IsWrappingVariable checks if a wrapper call (e.g., fmt.Errorf with %w or
+// errors.Wrap) carries the given variable into its result.
+func IsWrappingVariable(pass *analysis.Pass, call *ast.CallExpr, targetVar *types.Var) bool {
+	args, _ := WrappedArgs(pass, call)
+	for _, arg := range args {
+		if ident, ok := arg.(*ast.Ident); ok {
+			if pass.TypesInfo.Uses[ident] == targetVar {
+				return true
 			}
 		}
 	}
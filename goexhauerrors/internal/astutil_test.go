@@ -304,6 +304,33 @@ func TestFindWrapVerbIndices(t *testing.T) {
 	}
 }
 
+func TestFindFormatVerbs(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   []FormatVerb
+	}{
+		{"no verbs", "hello", nil},
+		{"single %v", "failed: %v", []FormatVerb{{Offset: 8, Text: "%v", Verb: 'v', ArgIndex: 0}}},
+		{"flags and %w", "%+v: %w", []FormatVerb{
+			{Offset: 0, Text: "%+v", Verb: 'v', ArgIndex: 0},
+			{Offset: 5, Text: "%w", Verb: 'w', ArgIndex: 1},
+		}},
+		{"%% skipped", "100%% %s", []FormatVerb{{Offset: 6, Text: "%s", Verb: 's', ArgIndex: 0}}},
+		{"width and precision", "%8.3f", []FormatVerb{{Offset: 0, Text: "%8.3f", Verb: 'f', ArgIndex: 0}}},
+		{"incomplete format at end", "%", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindFormatVerbs(tt.format)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindFormatVerbs(%q) = %+v, want %+v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSplitErrorKey(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"sync"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	checkFunctions   map[string][]string
+	checkFunctionsMu sync.RWMutex
+)
+
+// SetCheckFunctions sets the configured error-checking helper functions.
+// The format is a comma-separated list of "func=err|err" entries, where func is
+// the fully qualified function name (pkg/path.Func or pkg/path.Type.Method) and
+// each err is a fully qualified error key (pkg/path.ErrName or pkg/path.TypeName).
+// Helpers returning a code take "func=value=err|err" entries instead, where value is
+// the fully qualified constant the result is compared with.
+// Example: "example.com/apperr.IsNotFound=example.com/apperr.ErrNotFound"
+// Example: "example.com/apperr.Code=example.com/apperr.CodeNotFound=example.com/apperr.ErrNotFound"
+func SetCheckFunctions(s string) {
+	parsed := make(map[string][]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, errs, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		// Value-specific errors are kept as "value=err" keys
+		prefix := ""
+		if value, rest, ok := strings.Cut(errs, "="); ok {
+			prefix = strings.TrimSpace(value) + "="
+			errs = rest
+		}
+		for _, key := range strings.Split(errs, "|") {
+			if key = strings.TrimSpace(key); key != "" {
+				parsed[name] = append(parsed[name], prefix+key)
+			}
+		}
+	}
+
+	checkFunctionsMu.Lock()
+	checkFunctions = parsed
+	checkFunctionsMu.Unlock()
+}
+
+// configuredCheckErrors returns the error keys configured for the given function name.
+func configuredCheckErrors(funcName string) []string {
+	checkFunctionsMu.RLock()
+	defer checkFunctionsMu.RUnlock()
+	return checkFunctions[funcName]
+}
+
+// FuncFullName returns the fully qualified name of a function:
+// pkg/path.Func for functions and pkg/path.Type.Method for methods.
+func FuncFullName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if ok && sig.Recv() != nil {
+		if named := ExtractNamedType(sig.Recv().Type()); named != nil {
+			return fn.Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+		}
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// LookupCheckFunction returns the CheckFunctionFact for the function called by call.
+// Helpers annotated with //goexhauerrors:checks carry an exported fact; helpers
+// configured with -checkFunctions are resolved by name.
+// Returns nil if the call is not a known error-checking helper.
+func LookupCheckFunction(pass *analysis.Pass, call *ast.CallExpr) *facts.CheckFunctionFact {
+	fn := GetCalledFunction(pass, call)
+	if fn == nil {
+		return nil
+	}
+
+	var fact facts.CheckFunctionFact
+	if pass.ImportObjectFact(fn, &fact) {
+		return &fact
+	}
+
+	keys := configuredCheckErrors(FuncFullName(fn))
+	if len(keys) == 0 {
+		return nil
+	}
+
+	paramIndex := -1
+	params := fn.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		if IsErrorType(params.At(i).Type()) {
+			paramIndex = i
+			break
+		}
+	}
+	if paramIndex < 0 {
+		return nil
+	}
+
+	result := &facts.CheckFunctionFact{ParamIndex: paramIndex}
+	for _, key := range keys {
+		value, key, isValue := strings.Cut(key, "=")
+		if !isValue {
+			key = value
+		}
+		parts := SplitErrorKey(key)
+		if parts == nil {
+			continue
+		}
+		errInfo := facts.ErrorInfo{PkgPath: parts[0], Name: parts[1]}
+		if isValue {
+			result.AddValueError(value, errInfo)
+		} else {
+			result.AddError(errInfo)
+		}
+	}
+	return result
+}
+
+// LookupCodeComparison resolves a comparison of a code helper's result with a constant,
+// such as status.Code(err) == codes.NotFound, where call is status.Code(err) and value
+// is codes.NotFound. It returns the checked error argument and the errors the value
+// covers, or nil if call is not a code helper or value is not one of its constants.
+func LookupCodeComparison(pass *analysis.Pass, call, value ast.Expr) (ast.Expr, []facts.ErrorInfo) {
+	callExpr, ok := ast.Unparen(call).(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	checkFact := LookupCheckFunction(pass, callExpr)
+	if checkFact == nil || len(checkFact.Values) == 0 || checkFact.ParamIndex >= len(callExpr.Args) {
+		return nil, nil
+	}
+	key := ConstKey(pass, value)
+	if key == "" {
+		return nil, nil
+	}
+	errs := checkFact.ValueErrors(key)
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return callExpr.Args[checkFact.ParamIndex], errs
+}
+
+// ConstKey returns the fully qualified name (pkg/path.Name) of the package-level
+// constant expr refers to, or "" if it is not one.
+func ConstKey(pass *analysis.Pass, expr ast.Expr) string {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return ""
+	}
+	c, ok := pass.TypesInfo.Uses[ident].(*types.Const)
+	if !ok || c.Pkg() == nil || c.Parent() != c.Pkg().Scope() {
+		return ""
+	}
+	return c.Pkg().Path() + "." + c.Name()
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestSetCheckFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		funcName string
+		want     []string
+	}{
+		{"empty config", "", "pkg.IsNotFound", nil},
+		{"single entry", "pkg.IsNotFound=pkg.ErrNotFound", "pkg.IsNotFound", []string{"pkg.ErrNotFound"}},
+		{"multiple errors", "pkg.IsNotFound=pkg.ErrNotFound|pkg.ErrGone", "pkg.IsNotFound", []string{"pkg.ErrNotFound", "pkg.ErrGone"}},
+		{"multiple entries second", "pkg.IsA=pkg.ErrA, pkg.IsB=pkg.ErrB", "pkg.IsB", []string{"pkg.ErrB"}},
+		{"method entry", "example.com/x.Checker.IsA=example.com/x.ErrA", "example.com/x.Checker.IsA", []string{"example.com/x.ErrA"}},
+		{"code entry", "pkg.Code=pkg.CodeA=pkg.ErrA|pkg.ErrB", "pkg.Code", []string{"pkg.CodeA=pkg.ErrA", "pkg.CodeA=pkg.ErrB"}},
+		{"code entries", "pkg.Code=pkg.CodeA=pkg.ErrA, pkg.Code=pkg.CodeB=pkg.ErrB", "pkg.Code", []string{"pkg.CodeA=pkg.ErrA", "pkg.CodeB=pkg.ErrB"}},
+		{"entry without errors is ignored", "pkg.IsA", "pkg.IsA", nil},
+		{"no match", "pkg.IsA=pkg.ErrA", "pkg.IsB", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetCheckFunctions(tt.config)
+			defer SetCheckFunctions("")
+
+			got := configuredCheckErrors(tt.funcName)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("configuredCheckErrors(%q) with %q = %v, want %v", tt.funcName, tt.config, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncFullName(t *testing.T) {
+	pkg := types.NewPackage("example.com/apperr", "apperr")
+	sig := types.NewSignatureType(nil, nil, nil, nil, nil, false)
+	fn := types.NewFunc(token.NoPos, pkg, "IsNotFound", sig)
+
+	named := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Checker", nil), types.NewStruct(nil, nil), nil)
+	recv := types.NewVar(token.NoPos, pkg, "c", types.NewPointer(named))
+	methodSig := types.NewSignatureType(recv, nil, nil, nil, nil, false)
+	method := types.NewFunc(token.NoPos, pkg, "IsGone", methodSig)
+
+	universeFn := types.NewFunc(token.NoPos, nil, "Error", sig)
+
+	tests := []struct {
+		name string
+		fn   *types.Func
+		want string
+	}{
+		{"package function", fn, "example.com/apperr.IsNotFound"},
+		{"pointer receiver method", method, "example.com/apperr.Checker.IsGone"},
+		{"no package", universeFn, "Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FuncFullName(tt.fn); got != tt.want {
+				t.Errorf("FuncFullName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// directivePrefix is the prefix shared by all goexhauerrors comment directives.
+const directivePrefix = "//goexhauerrors:"
+
+// Directive is a parsed //goexhauerrors:<name> comment.
+type Directive struct {
+	Name string    // Directive name (e.g., "checks")
+	Args []string  // Whitespace-separated arguments following the name
+	Pos  token.Pos // Position of the comment
+}
+
+// FindDirective returns the first //goexhauerrors:<name> directive in the comment group.
+func FindDirective(doc *ast.CommentGroup, name string) (Directive, bool) {
+	if doc == nil {
+		return Directive{}, false
+	}
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, directivePrefix) {
+			continue
+		}
+		text := strings.TrimPrefix(c.Text, directivePrefix)
+		// Allow a trailing comment after the arguments
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 || fields[0] != name {
+			continue
+		}
+		return Directive{Name: name, Args: fields[1:], Pos: c.Pos()}, true
+	}
+	return Directive{}, false
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+)
+
+func TestFindDirective(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		want     []string
+		wantOK   bool
+	}{
+		{"nil doc", nil, nil, false},
+		{"no directive", []string{"// IsNotFound reports not found."}, nil, false},
+		{"single arg", []string{"//goexhauerrors:checks ErrNotFound"}, []string{"ErrNotFound"}, true},
+		{"multiple args", []string{"// doc", "//goexhauerrors:checks ErrA pkg.ErrB"}, []string{"ErrA", "pkg.ErrB"}, true},
+		{"no args", []string{"//goexhauerrors:checks"}, []string{}, true},
+		{"other directive", []string{"//goexhauerrors:mock"}, nil, false},
+		{"space after slashes is not a directive", []string{"// goexhauerrors:checks ErrA"}, nil, false},
+		{"trailing comment", []string{"//goexhauerrors:checks ErrA // note"}, []string{"ErrA"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc *ast.CommentGroup
+			if tt.comments != nil {
+				doc = &ast.CommentGroup{}
+				for _, text := range tt.comments {
+					doc.List = append(doc.List, &ast.Comment{Text: text})
+				}
+			}
+			got, ok := FindDirective(doc, "checks")
+			if ok != tt.wantOK {
+				t.Fatalf("FindDirective() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got.Args, tt.want) {
+				t.Errorf("FindDirective().Args = %v, want %v", got.Args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"golang.org/x/tools/go/analysis"
+)
+
+// WrapperFunc describes a function whose error result carries one or more of its arguments.
+type WrapperFunc struct {
+	ArgIndex    int  // Index of the error argument carried to the result, or -1
+	FormatIndex int  // Index of a format string whose %w verbs select the carried arguments, or -1
+	Causer      bool // The result has a Cause method returning the argument, which pkg/errors.Cause unwraps
+	Unwraps     bool // The result is the root cause of the argument (e.g., pkg/errors.Cause)
+	UnwrapsAll  bool // Unwraps also goes through Unwrap methods, not only Cause methods (cockroachdb)
+}
+
+// CheckerKind classifies a function that inspects an error chain.
+type CheckerKind int
+
+const (
+	// CheckerIs compares the error chain against sentinel targets (errors.Is semantics).
+	// Every argument after the first is treated as a target.
+	CheckerIs CheckerKind = iota + 1
+	// CheckerAs extracts a custom error type from the chain (errors.As semantics).
+	CheckerAs
+)
+
+// builtinWrappers lists the wrapping APIs of the standard library and popular error packages.
+var builtinWrappers = map[string]WrapperFunc{
+	"fmt.Errorf": {ArgIndex: -1, FormatIndex: 0},
+
+	"github.com/pkg/errors.Wrap":         {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/pkg/errors.Wrapf":        {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/pkg/errors.WithStack":    {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/pkg/errors.WithMessage":  {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/pkg/errors.WithMessagef": {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/pkg/errors.Cause":        {ArgIndex: 0, FormatIndex: -1, Unwraps: true},
+
+	"github.com/cockroachdb/errors.Wrap":         {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.Wrapf":        {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithStack":    {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithMessage":  {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithMessagef": {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithDetail":   {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithDetailf":  {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithHint":     {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.WithHintf":    {ArgIndex: 0, FormatIndex: -1, Causer: true},
+	"github.com/cockroachdb/errors.Errorf":       {ArgIndex: -1, FormatIndex: 0},
+	"github.com/cockroachdb/errors.Newf":         {ArgIndex: -1, FormatIndex: 0},
+	"github.com/cockroachdb/errors.Cause":        {ArgIndex: 0, FormatIndex: -1, Unwraps: true, UnwrapsAll: true},
+	"github.com/cockroachdb/errors.UnwrapAll":    {ArgIndex: 0, FormatIndex: -1, Unwraps: true, UnwrapsAll: true},
+
+	"golang.org/x/xerrors.Errorf": {ArgIndex: -1, FormatIndex: 0},
+}
+
+// builtinCheckers lists the errors.Is/As equivalents of the standard library and popular error packages.
+var builtinCheckers = map[string]CheckerKind{
+	"errors.Is": CheckerIs,
+	"errors.As": CheckerAs,
+
+	"github.com/pkg/errors.Is": CheckerIs,
+	"github.com/pkg/errors.As": CheckerAs,
+
+	"github.com/cockroachdb/errors.Is":            CheckerIs,
+	"github.com/cockroachdb/errors.IsAny":         CheckerIs,
+	"github.com/cockroachdb/errors.As":            CheckerAs,
+	"github.com/cockroachdb/errors/markers.Is":    CheckerIs,
+	"github.com/cockroachdb/errors/markers.IsAny": CheckerIs,
+
+	"golang.org/x/xerrors.Is": CheckerIs,
+	"golang.org/x/xerrors.As": CheckerAs,
+}
+
+// builtinConstructors lists functions that create a new, non-wrapping error value.
+// Package-level variables initialized with these are detected as sentinel errors.
+var builtinConstructors = map[string]bool{
+	"errors.New": true,
+
+	"github.com/pkg/errors.New":    true,
+	"github.com/pkg/errors.Errorf": true,
+
+	"github.com/cockroachdb/errors.New": true,
+
+	"golang.org/x/xerrors.New": true,
+}
+
+var (
+	wrapperFunctions map[string]WrapperFunc
+	checkerFunctions map[string]CheckerKind
+	errorLibrariesMu sync.RWMutex
+)
+
+// SetWrapperFunctions sets additional wrapper functions on top of the built-in presets.
+// The format is a comma-separated list of "func=spec" entries, where func is the fully
+// qualified function name and spec is one of:
+//   - N: the error argument at index N is carried to the result
+//   - format:N: the argument at index N is a format string whose %w verbs select the carried arguments
+//   - cause:N: the result is the root cause of the error argument at index N, as with pkg/errors.Cause
+//
+// Example: "example.com/errs.Annotate=0,example.com/errs.Newf=format:0"
+func SetWrapperFunctions(s string) {
+	parsed := make(map[string]WrapperFunc)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if w, ok := parseWrapperSpec(strings.TrimSpace(spec)); ok {
+			parsed[strings.TrimSpace(name)] = w
+		}
+	}
+
+	errorLibrariesMu.Lock()
+	wrapperFunctions = parsed
+	errorLibrariesMu.Unlock()
+}
+
+// parseWrapperSpec parses the right-hand side of a -wrapperFunctions entry.
+func parseWrapperSpec(spec string) (WrapperFunc, bool) {
+	w := WrapperFunc{ArgIndex: -1, FormatIndex: -1}
+	kind, idxStr, hasKind := strings.Cut(spec, ":")
+	if !hasKind {
+		idxStr = kind
+	}
+	idx, err := strconv.Atoi(idxStr)
+	if err != nil || idx < 0 {
+		return w, false
+	}
+	switch {
+	case !hasKind:
+		w.ArgIndex = idx
+	case kind == "format":
+		w.FormatIndex = idx
+	case kind == "cause":
+		w.ArgIndex = idx
+		w.Unwraps = true
+	default:
+		return w, false
+	}
+	return w, true
+}
+
+// SetCheckerFunctions sets additional errors.Is/As equivalents on top of the built-in presets.
+// The format is a comma-separated list of "func=Is" or "func=As" entries.
+// Example: "example.com/errs.Matches=Is,example.com/errs.Extract=As"
+func SetCheckerFunctions(s string) {
+	parsed := make(map[string]CheckerKind)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, kind, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(kind) {
+		case "Is":
+			parsed[strings.TrimSpace(name)] = CheckerIs
+		case "As":
+			parsed[strings.TrimSpace(name)] = CheckerAs
+		}
+	}
+
+	errorLibrariesMu.Lock()
+	checkerFunctions = parsed
+	errorLibrariesMu.Unlock()
+}
+
+// LookupWrapper returns the wrapper description of fn, if it is a known wrapper function.
+func LookupWrapper(fn *types.Func) (WrapperFunc, bool) {
+	name := FuncFullName(fn)
+	if w, ok := builtinWrappers[name]; ok {
+		return w, true
+	}
+	errorLibrariesMu.RLock()
+	defer errorLibrariesMu.RUnlock()
+	w, ok := wrapperFunctions[name]
+	return w, ok
+}
+
+// LookupChecker returns the checker kind of fn, if it is a known errors.Is/As equivalent.
+func LookupChecker(fn *types.Func) (CheckerKind, bool) {
+	name := FuncFullName(fn)
+	if k, ok := builtinCheckers[name]; ok {
+		return k, true
+	}
+	errorLibrariesMu.RLock()
+	defer errorLibrariesMu.RUnlock()
+	k, ok := checkerFunctions[name]
+	return k, ok
+}
+
+// IsErrorConstructor checks if fn creates a new, non-wrapping error (e.g., errors.New).
+func IsErrorConstructor(fn *types.Func) bool {
+	return builtinConstructors[FuncFullName(fn)]
+}
+
+// WrappedArgs returns the arguments whose errors are carried to the result of a
+// wrapper call (e.g., the %w operands of fmt.Errorf or the first argument of
+// errors.Wrap). The second result reports whether call is a known wrapper at all.
+func WrappedArgs(pass *analysis.Pass, call *ast.CallExpr) ([]ast.Expr, bool) {
+	fn := GetCalledFunction(pass, call)
+	if fn == nil {
+		return nil, false
+	}
+	w, ok := LookupWrapper(fn)
+	if !ok {
+		return nil, false
+	}
+
+	var args []ast.Expr
+	if w.ArgIndex >= 0 && w.ArgIndex < len(call.Args) {
+		args = append(args, call.Args[w.ArgIndex])
+	}
+	if w.FormatIndex >= 0 && w.FormatIndex < len(call.Args) {
+		formatStr := ExtractStringLiteral(call.Args[w.FormatIndex])
+		for _, wrapIdx := range FindWrapVerbIndices(formatStr) {
+			argIdx := w.FormatIndex + 1 + wrapIdx
+			if argIdx < len(call.Args) {
+				args = append(args, call.Args[argIdx])
+			}
+		}
+	}
+	return args, true
+}
+
+// Carry returns errInfo as carried to the result of a call of w. A wrapper wraps it,
+// hiding it from pkg/errors.Cause unless the result has a Cause method. A root-cause
+// function returns it unwrapped if it reaches it, and leaves it as it is otherwise.
+func (w WrapperFunc) Carry(errInfo facts.ErrorInfo) facts.ErrorInfo {
+	errInfo.Wrapped, errInfo.WrappedBy, errInfo.CauseHidden = w.carry(errInfo.Wrapped, errInfo.WrappedBy, errInfo.CauseHidden)
+	return errInfo
+}
+
+// CarryFlow is Carry for a parameter flowing to the argument of a call of w.
+func (w WrapperFunc) CarryFlow(flow facts.ParameterFlowInfo) facts.ParameterFlowInfo {
+	flow.Wrapped, flow.WrappedBy, flow.CauseHidden = w.carry(flow.Wrapped, flow.WrappedBy, flow.CauseHidden)
+	return flow
+}
+
+// CarryCallFlow is Carry for the result of a function parameter call passed to a call of w.
+func (w WrapperFunc) CarryCallFlow(flow facts.FunctionParamCallFlowInfo) facts.FunctionParamCallFlowInfo {
+	flow.Wrapped, _, flow.CauseHidden = w.carry(flow.Wrapped, "", flow.CauseHidden)
+	return flow
+}
+
+func (w WrapperFunc) carry(wrapped bool, wrappedBy string, causeHidden bool) (bool, string, bool) {
+	if !w.Unwraps {
+		return true, wrappedBy, causeHidden || !w.Causer
+	}
+	if w.reaches(wrapped, wrappedBy, causeHidden) {
+		return false, "", false
+	}
+	return wrapped, wrappedBy, causeHidden
+}
+
+// Reaches reports whether the root-cause function w returns errInfo itself when it
+// arrives wrapped as described: pkg/errors.Cause only unwraps wrappers with a Cause
+// method, so not fmt.Errorf %w or a wrapper type. cockroachdb's Cause unwraps any wrapper.
+func (w WrapperFunc) Reaches(errInfo facts.ErrorInfo) bool {
+	return w.reaches(errInfo.Wrapped, errInfo.WrappedBy, errInfo.CauseHidden)
+}
+
+func (w WrapperFunc) reaches(wrapped bool, wrappedBy string, causeHidden bool) bool {
+	return w.UnwrapsAll || !wrapped || (wrappedBy == "" && !causeHidden)
+}
+
+// UnwrapCauseExpr strips calls to root-cause functions such as pkg/errors.Cause, so
+// that `switch errors.Cause(err) { case ErrX: }` is treated like `switch err`. It also
+// returns the stripped root-cause function; its Unwraps is false if there was none.
+func UnwrapCauseExpr(pass *analysis.Pass, expr ast.Expr) (ast.Expr, WrapperFunc) {
+	var cause WrapperFunc
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return expr, cause
+		}
+		fn := GetCalledFunction(pass, call)
+		if fn == nil {
+			return expr, cause
+		}
+		w, ok := LookupWrapper(fn)
+		if !ok || !w.Unwraps || w.ArgIndex >= len(call.Args) {
+			return expr, cause
+		}
+		if !cause.UnwrapsAll {
+			cause = w
+		}
+		expr = call.Args[w.ArgIndex]
+	}
+}
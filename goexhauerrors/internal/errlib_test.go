@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+)
+
+func newTestFunc(pkgPath, name string) *types.Func {
+	pkg := types.NewPackage(pkgPath, pkgPath)
+	sig := types.NewSignatureType(nil, nil, nil, nil, nil, false)
+	return types.NewFunc(token.NoPos, pkg, name, sig)
+}
+
+func TestParseWrapperSpec(t *testing.T) {
+	tests := []struct {
+		spec   string
+		want   WrapperFunc
+		wantOK bool
+	}{
+		{"0", WrapperFunc{ArgIndex: 0, FormatIndex: -1}, true},
+		{"2", WrapperFunc{ArgIndex: 2, FormatIndex: -1}, true},
+		{"format:1", WrapperFunc{ArgIndex: -1, FormatIndex: 1}, true},
+		{"cause:0", WrapperFunc{ArgIndex: 0, FormatIndex: -1, Unwraps: true}, true},
+		{"", WrapperFunc{}, false},
+		{"-1", WrapperFunc{}, false},
+		{"x", WrapperFunc{}, false},
+		{"stack:0", WrapperFunc{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, ok := parseWrapperSpec(tt.spec)
+			if ok != tt.wantOK {
+				t.Fatalf("parseWrapperSpec(%q) ok = %v, want %v", tt.spec, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseWrapperSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupWrapper(t *testing.T) {
+	SetWrapperFunctions("example.com/errs.Annotate=1, example.com/errs.Newf=format:0, bad")
+	defer SetWrapperFunctions("")
+
+	tests := []struct {
+		name   string
+		fn     *types.Func
+		want   WrapperFunc
+		wantOK bool
+	}{
+		{"fmt.Errorf preset", newTestFunc("fmt", "Errorf"), WrapperFunc{ArgIndex: -1, FormatIndex: 0}, true},
+		{"pkg/errors Wrap preset", newTestFunc("github.com/pkg/errors", "Wrap"), WrapperFunc{ArgIndex: 0, FormatIndex: -1, Causer: true}, true},
+		{"pkg/errors Cause preset", newTestFunc("github.com/pkg/errors", "Cause"), WrapperFunc{ArgIndex: 0, FormatIndex: -1, Unwraps: true}, true},
+		{"xerrors Errorf preset", newTestFunc("golang.org/x/xerrors", "Errorf"), WrapperFunc{ArgIndex: -1, FormatIndex: 0}, true},
+		{"configured arg", newTestFunc("example.com/errs", "Annotate"), WrapperFunc{ArgIndex: 1, FormatIndex: -1}, true},
+		{"configured format", newTestFunc("example.com/errs", "Newf"), WrapperFunc{ArgIndex: -1, FormatIndex: 0}, true},
+		{"unknown", newTestFunc("example.com/errs", "New"), WrapperFunc{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LookupWrapper(tt.fn)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("LookupWrapper() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookupChecker(t *testing.T) {
+	SetCheckerFunctions("example.com/errs.Matches=Is, example.com/errs.Extract=As, example.com/errs.Bad=Maybe")
+	defer SetCheckerFunctions("")
+
+	tests := []struct {
+		name   string
+		fn     *types.Func
+		want   CheckerKind
+		wantOK bool
+	}{
+		{"errors.Is", newTestFunc("errors", "Is"), CheckerIs, true},
+		{"errors.As", newTestFunc("errors", "As"), CheckerAs, true},
+		{"cockroachdb IsAny", newTestFunc("github.com/cockroachdb/errors", "IsAny"), CheckerIs, true},
+		{"cockroachdb markers.Is", newTestFunc("github.com/cockroachdb/errors/markers", "Is"), CheckerIs, true},
+		{"configured Is", newTestFunc("example.com/errs", "Matches"), CheckerIs, true},
+		{"configured As", newTestFunc("example.com/errs", "Extract"), CheckerAs, true},
+		{"invalid kind is ignored", newTestFunc("example.com/errs", "Bad"), 0, false},
+		{"errors.Unwrap", newTestFunc("errors", "Unwrap"), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LookupChecker(tt.fn)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("LookupChecker() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWrapperFuncCarry(t *testing.T) {
+	pkgWrap := builtinWrappers["github.com/pkg/errors.Wrap"]
+	fmtErrorf := builtinWrappers["fmt.Errorf"]
+	pkgCause := builtinWrappers["github.com/pkg/errors.Cause"]
+	crdbCause := builtinWrappers["github.com/cockroachdb/errors.Cause"]
+
+	direct := facts.ErrorInfo{PkgPath: "example.com/app", Name: "ErrNotFound"}
+	tests := []struct {
+		name     string
+		wrappers []WrapperFunc
+		want     facts.ErrorInfo
+	}{
+		{"pkg/errors wrapper", []WrapperFunc{pkgWrap}, facts.ErrorInfo{PkgPath: "example.com/app", Name: "ErrNotFound", Wrapped: true}},
+		{"fmt.Errorf hides from Cause", []WrapperFunc{fmtErrorf}, facts.ErrorInfo{PkgPath: "example.com/app", Name: "ErrNotFound", Wrapped: true, CauseHidden: true}},
+		{"Cause of pkg/errors wrapper", []WrapperFunc{pkgWrap, pkgCause}, direct},
+		{"Cause of fmt.Errorf", []WrapperFunc{fmtErrorf, pkgWrap, pkgCause}, facts.ErrorInfo{PkgPath: "example.com/app", Name: "ErrNotFound", Wrapped: true, CauseHidden: true}},
+		{"cockroachdb Cause of fmt.Errorf", []WrapperFunc{fmtErrorf, crdbCause}, direct},
+		{"Cause of unwrapped error", []WrapperFunc{pkgCause}, direct},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := direct
+			for _, w := range tt.wrappers {
+				got = w.Carry(got)
+			}
+			if got != tt.want {
+				t.Errorf("Carry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsErrorConstructor(t *testing.T) {
+	tests := []struct {
+		fn   *types.Func
+		want bool
+	}{
+		{newTestFunc("errors", "New"), true},
+		{newTestFunc("github.com/pkg/errors", "New"), true},
+		{newTestFunc("github.com/cockroachdb/errors", "New"), true},
+		{newTestFunc("golang.org/x/xerrors", "New"), true},
+		{newTestFunc("fmt", "Errorf"), false},
+		{newTestFunc("example.com/errs", "New"), false},
+	}
+	for _, tt := range tests {
+		t.Run(FuncFullName(tt.fn), func(t *testing.T) {
+			if got := IsErrorConstructor(tt.fn); got != tt.want {
+				t.Errorf("IsErrorConstructor(%s) = %v, want %v", FuncFullName(tt.fn), got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package internal
 
 import (
+	"go/ast"
 	"go/types"
+	"sort"
 
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
@@ -12,6 +15,10 @@ import (
 type InterfaceImplementations struct {
 	// implementations maps interface types to their implementing named types
 	implementations map[*types.Interface][]*types.Named
+	// imports holds the transitive imports of the package, computed once per pass
+	imports []*types.Package
+	// declared holds the declared interfaces with error-returning methods, in scope order
+	declared []*types.TypeName
 }
 
 // NewInterfaceImplementations creates a new interface implementations cache.
@@ -22,20 +29,36 @@ func NewInterfaceImplementations() *InterfaceImplementations {
 }
 
 // FindInterfaceImplementations discovers all interface implementations visible from the package.
-// It scans named types in the current package and all directly imported packages,
-// and checks if they implement any interfaces also visible from the package.
+// Interfaces are collected from the current package and all transitively imported packages,
+// so that an implementation of an interface two imports away is recognized. Candidate
+// types are the named types of the current package (including types declared inside
+// functions) and of its directly imported packages, except mocks. Implementations in
+// other packages are found through ImplementsFact instead. Interfaces without a method
+// returning an error are skipped, since their implementations carry no errors.
 func FindInterfaceImplementations(pass *analysis.Pass) *InterfaceImplementations {
 	impl := NewInterfaceImplementations()
+	impl.imports = TransitiveImports(pass.Pkg)
 
 	// Use inspector to ensure it's available (required dependency)
 	_ = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	// Collect interfaces from current package + imported packages
+	// Collect interfaces from current package + transitively imported packages.
+	// Implementations of interfaces without error-returning methods carry no errors.
+	var declared []*types.TypeName
+	collectInterfaces(pass.Pkg.Scope(), &declared)
+	for _, imp := range impl.imports {
+		collectInterfaces(imp.Scope(), &declared)
+	}
 	var interfaces []*types.Interface
-	collectInterfaces(pass.Pkg.Scope(), &interfaces)
-	for _, imp := range pass.Pkg.Imports() {
-		collectInterfaces(imp.Scope(), &interfaces)
+	for _, typeName := range declared {
+		iface := typeName.Type().Underlying().(*types.Interface)
+		if HasErrorReturningMethod(iface) {
+			impl.declared = append(impl.declared, typeName)
+			interfaces = append(interfaces, iface)
+		}
 	}
+	// Constraints written inline ([R interface{ Get(int) error }]) are not declared types
+	interfaces = append(interfaces, inlineConstraints(pass, interfaces)...)
 
 	if len(interfaces) == 0 {
 		return impl
@@ -44,13 +67,26 @@ func FindInterfaceImplementations(pass *analysis.Pass) *InterfaceImplementations
 	// Collect named types from current package + imported packages
 	var namedTypes []*types.Named
 	collectNamedTypes(pass.Pkg.Scope(), &namedTypes)
-	for _, imp := range pass.Pkg.Imports() {
+	namedTypes = append(namedTypes, LocalNamedTypes(pass)...)
+	for _, imp := range SortedImports(pass.Pkg) {
 		collectNamedTypes(imp.Scope(), &namedTypes)
 	}
 
+	// Mocks do not contribute to the error sets of the interfaces they implement
+	candidates := namedTypes[:0]
+	for _, named := range namedTypes {
+		if !IsMockType(pass, named) {
+			candidates = append(candidates, named)
+		}
+	}
+	namedTypes = candidates
+
 	// For each interface, find implementing types
 	for _, iface := range interfaces {
 		for _, named := range namedTypes {
+			if delegatesToEmbeddedInterface(named, iface) {
+				continue
+			}
 			if types.Implements(named, iface) {
 				impl.implementations[iface] = append(impl.implementations[iface], named)
 			} else if ptr := types.NewPointer(named); types.Implements(ptr, iface) {
@@ -62,16 +98,109 @@ func FindInterfaceImplementations(pass *analysis.Pass) *InterfaceImplementations
 	return impl
 }
 
-// collectInterfaces scans a scope and appends all interface types found.
-func collectInterfaces(scope *types.Scope, interfaces *[]*types.Interface) {
+// delegatesToEmbeddedInterface reports whether named has the methods of iface only through
+// interfaces embedded in it (type Service struct{ Repository }). Such a type forwards
+// to whatever value it embeds and adds no implementation of its own.
+func delegatesToEmbeddedInterface(named *types.Named, iface *types.Interface) bool {
+	if iface.NumMethods() == 0 {
+		return false
+	}
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := FindMethodImplementation(named, iface.Method(i))
+		if method == nil {
+			return false
+		}
+		if !IsAbstractMethod(method) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAbstractMethod reports whether method is an interface method, such as the Get that
+// type Service struct{ Repository } promotes from its embedded interface.
+// Such a method has no body and no facts; its errors come from the value it forwards to.
+func IsAbstractMethod(method *types.Func) bool {
+	recv := method.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	_, abstract := recv.Type().Underlying().(*types.Interface)
+	return abstract
+}
+
+// LocalNamedTypes returns the non-interface named types declared inside functions of
+// the current package, in source order. Such types have no methods of their own but
+// can implement interfaces through embedded fields.
+func LocalNamedTypes(pass *analysis.Pass) []*types.Named {
+	var result []*types.Named
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			tn, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+			if !ok || tn.Parent() == pass.Pkg.Scope() {
+				return true
+			}
+			if named, ok := tn.Type().(*types.Named); ok {
+				if _, isIface := named.Underlying().(*types.Interface); !isIface {
+					result = append(result, named)
+				}
+			}
+			return true
+		})
+	}
+	return result
+}
+
+// inlineConstraints returns the constraint interfaces with methods of the type parameters
+// declared in the current package that are not in declared yet, in source order.
+func inlineConstraints(pass *analysis.Pass, declared []*types.Interface) []*types.Interface {
+	seen := make(map[*types.Interface]bool)
+	for _, iface := range declared {
+		seen[iface] = true
+	}
+	var result []*types.Interface
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			field, ok := n.(*ast.Field)
+			if !ok {
+				return true
+			}
+			for _, name := range field.Names {
+				tn, ok := pass.TypesInfo.Defs[name].(*types.TypeName)
+				if !ok {
+					continue
+				}
+				tp, ok := tn.Type().(*types.TypeParam)
+				if !ok {
+					continue
+				}
+				iface, ok := tp.Constraint().Underlying().(*types.Interface)
+				if !ok || iface.NumMethods() == 0 || seen[iface] {
+					continue
+				}
+				seen[iface] = true
+				result = append(result, iface)
+			}
+			return true
+		})
+	}
+	return result
+}
+
+// collectInterfaces scans a scope and appends the type names of all interface types found.
+func collectInterfaces(scope *types.Scope, typeNames *[]*types.TypeName) {
 	for _, name := range scope.Names() {
 		obj := scope.Lookup(name)
 		typeName, ok := obj.(*types.TypeName)
 		if !ok {
 			continue
 		}
-		if iface, ok := typeName.Type().Underlying().(*types.Interface); ok {
-			*interfaces = append(*interfaces, iface)
+		if _, ok := typeName.Type().Underlying().(*types.Interface); ok {
+			*typeNames = append(*typeNames, typeName)
 		}
 	}
 }
@@ -99,6 +228,44 @@ func (impl *InterfaceImplementations) GetImplementingTypes(iface *types.Interfac
 	return impl.implementations[iface]
 }
 
+// ImplementedInterfaces returns the declared interfaces, of the current package and its
+// transitive imports, that have at least one implementation, in scope order.
+func (impl *InterfaceImplementations) ImplementedInterfaces() []*types.TypeName {
+	var result []*types.TypeName
+	for _, typeName := range impl.declared {
+		if len(impl.implementations[typeName.Type().Underlying().(*types.Interface)]) > 0 {
+			result = append(result, typeName)
+		}
+	}
+	return result
+}
+
+// Imports returns the packages transitively imported by the analyzed package, ordered by path.
+func (impl *InterfaceImplementations) Imports() []*types.Package {
+	return impl.imports
+}
+
+// HasErrorReturningMethod checks if any method of the interface returns an error,
+// directly or through an iterator yielding errors (iter.Seq2[T, error]).
+// Interfaces without such methods (e.g., fmt.Stringer) are irrelevant to error checking.
+func HasErrorReturningMethod(iface *types.Interface) bool {
+	for i := 0; i < iface.NumMethods(); i++ {
+		sig, ok := iface.Method(i).Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+		if len(FindErrorReturnPositions(sig)) > 0 {
+			return true
+		}
+		for j := 0; j < sig.Results().Len(); j++ {
+			if IteratorYieldErrorIndex(sig.Results().At(j).Type()) >= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // FindMethodImplementation finds the concrete method on a type that implements
 // an interface method.
 func FindMethodImplementation(concreteType *types.Named, ifaceMethod *types.Func) *types.Func {
@@ -125,11 +292,49 @@ func FindMethodImplementation(concreteType *types.Named, ifaceMethod *types.Func
 	return nil
 }
 
+// SortedImports returns the packages imported by pkg, ordered by path.
+// The order of types.Package.Imports depends on how the driver loaded the package,
+// and iterating it directly would make facts differ between drivers.
+func SortedImports(pkg *types.Package) []*types.Package {
+	imports := append([]*types.Package(nil), pkg.Imports()...)
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Path() < imports[j].Path()
+	})
+	return imports
+}
+
+// TransitiveImports returns all packages reachable through imports from pkg,
+// excluding pkg itself, ordered by path.
+func TransitiveImports(pkg *types.Package) []*types.Package {
+	seen := make(map[*types.Package]bool)
+	var result []*types.Package
+	queue := pkg.Imports()
+	for len(queue) > 0 {
+		imp := queue[0]
+		queue = queue[1:]
+		if seen[imp] || imp == pkg {
+			continue
+		}
+		seen[imp] = true
+		result = append(result, imp)
+		queue = append(queue, imp.Imports()...)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path() < result[j].Path()
+	})
+	return result
+}
+
 // GetInterfaceType extracts the interface type from a type, handling pointers.
+// The interface of a type parameter is its constraint.
 func GetInterfaceType(t types.Type) *types.Interface {
 	switch typ := t.(type) {
 	case *types.Interface:
 		return typ
+	case *types.TypeParam:
+		if iface, ok := typ.Constraint().Underlying().(*types.Interface); ok {
+			return iface
+		}
 	case *types.Named:
 		if iface, ok := typ.Underlying().(*types.Interface); ok {
 			return iface
@@ -139,3 +344,58 @@ func GetInterfaceType(t types.Type) *types.Interface {
 	}
 	return nil
 }
+
+// InterfaceMethodByName returns the method of iface, including embedded methods, with
+// the given name, or nil.
+func InterfaceMethodByName(iface *types.Interface, name string) *types.Func {
+	for i := 0; i < iface.NumMethods(); i++ {
+		if method := iface.Method(i); method.Name() == name {
+			return method
+		}
+	}
+	return nil
+}
+
+// LookupMethod returns the method with the given name of a concrete type or a pointer
+// to it, or nil.
+func LookupMethod(t types.Type, name string) *types.Func {
+	named := ExtractNamedType(t)
+	if named == nil {
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, named.Obj().Pkg(), name)
+	method, _ := obj.(*types.Func)
+	return method
+}
+
+// DeclaringInterface returns the named interface declaring an interface method. It differs
+// from the interface a call goes through when the method is inherited by embedding
+// (type ReadWriter interface{ Reader; Writer }), and facts and index entries are keyed by it.
+// It returns nil for methods of interface literals and for concrete methods.
+func DeclaringInterface(method *types.Func) *types.TypeName {
+	sig, ok := method.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return nil
+	}
+	named, ok := types.Unalias(sig.Recv().Type()).(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Interface); !ok {
+		return nil
+	}
+	return named.Obj()
+}
+
+// InterfaceMethodKey returns the implementation index key of method called through the
+// interface named ifaceName, following embedding to the interface declaring the method.
+// ifaceName may be nil for interfaces without a name. It returns "" if neither is named.
+func InterfaceMethodKey(ifaceName *types.TypeName, method *types.Func) string {
+	if declaring := DeclaringInterface(method); declaring != nil {
+		ifaceName = declaring
+	}
+	if ifaceName == nil || ifaceName.Pkg() == nil {
+		return ""
+	}
+	return facts.InterfaceMethodKey(ifaceName.Pkg().Path(), ifaceName.Name(), method.Name())
+}
@@ -134,3 +134,141 @@ func TestFindMethodImplementation(t *testing.T) {
 		}
 	})
 }
+
+func TestIsAbstractMethod(t *testing.T) {
+	pkg := types.NewPackage("example.com/pkg", "pkg")
+
+	// Create an interface: type Repository interface{ Get() error }
+	errType := types.Universe.Lookup("error").Type()
+	getSig := types.NewSignatureType(
+		nil, nil, nil,
+		nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", errType)),
+		false,
+	)
+	getMethod := types.NewFunc(token.NoPos, pkg, "Get", getSig)
+	iface := types.NewInterfaceType([]*types.Func{getMethod}, nil)
+	iface.Complete()
+	repo := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Repository", nil), iface, nil)
+
+	// Create a struct embedding it: type Service struct{ Repository }
+	embedded := types.NewField(token.NoPos, pkg, "Repository", repo, true)
+	service := types.NewNamed(
+		types.NewTypeName(token.NoPos, pkg, "Service", nil),
+		types.NewStruct([]*types.Var{embedded}, nil),
+		nil,
+	)
+
+	// Create a type with its own Get method
+	impl := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Impl", nil), types.NewStruct(nil, nil), nil)
+	implSig := types.NewSignatureType(
+		types.NewVar(token.NoPos, pkg, "i", impl), nil, nil,
+		nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", errType)),
+		false,
+	)
+	impl.AddMethod(types.NewFunc(token.NoPos, pkg, "Get", implSig))
+
+	t.Run("method promoted from embedded interface", func(t *testing.T) {
+		method := FindMethodImplementation(service, getMethod)
+		if method == nil {
+			t.Fatal("FindMethodImplementation() = nil, want non-nil")
+		}
+		if !IsAbstractMethod(method) {
+			t.Error("IsAbstractMethod() = false, want true")
+		}
+	})
+
+	t.Run("concrete method", func(t *testing.T) {
+		method := FindMethodImplementation(impl, getMethod)
+		if method == nil {
+			t.Fatal("FindMethodImplementation() = nil, want non-nil")
+		}
+		if IsAbstractMethod(method) {
+			t.Error("IsAbstractMethod() = true, want false")
+		}
+	})
+
+	t.Run("function without receiver", func(t *testing.T) {
+		sig := types.NewSignatureType(nil, nil, nil, nil, nil, false)
+		fn := types.NewFunc(token.NoPos, pkg, "Get", sig)
+		if IsAbstractMethod(fn) {
+			t.Error("IsAbstractMethod() = true, want false")
+		}
+	})
+}
+
+func TestHasErrorReturningMethod(t *testing.T) {
+	pkg := types.NewPackage("example.com/pkg", "pkg")
+	errType := types.Universe.Lookup("error").Type()
+	stringType := types.Typ[types.String]
+
+	// newInterface creates interface{ M() <result> }
+	newInterface := func(result types.Type) *types.Interface {
+		sig := types.NewSignatureType(
+			nil, nil, nil,
+			nil,
+			types.NewTuple(types.NewVar(token.NoPos, nil, "", result)),
+			false,
+		)
+		iface := types.NewInterfaceType([]*types.Func{types.NewFunc(token.NoPos, pkg, "M", sig)}, nil)
+		iface.Complete()
+		return iface
+	}
+
+	// Create an iterator type: func(yield func(string, error) bool)
+	yield := types.NewSignatureType(
+		nil, nil, nil,
+		types.NewTuple(
+			types.NewVar(token.NoPos, nil, "", stringType),
+			types.NewVar(token.NoPos, nil, "", errType),
+		),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.Bool])),
+		false,
+	)
+	seq := types.NewSignatureType(
+		nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "yield", yield)),
+		nil,
+		false,
+	)
+
+	tests := []struct {
+		name  string
+		iface *types.Interface
+		want  bool
+	}{
+		{"returns error", newInterface(errType), true},
+		{"returns iterator of errors", newInterface(seq), true},
+		{"returns string", newInterface(stringType), false},
+		{"empty interface", types.NewInterfaceType(nil, nil).Complete(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasErrorReturningMethod(tt.iface); got != tt.want {
+				t.Errorf("HasErrorReturningMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitiveImports(t *testing.T) {
+	domain := types.NewPackage("example.com/domain", "domain")
+	infra := types.NewPackage("example.com/infra", "infra")
+	infra.SetImports([]*types.Package{domain})
+	cached := types.NewPackage("example.com/cached", "cached")
+	cached.SetImports([]*types.Package{infra})
+	app := types.NewPackage("example.com/app", "app")
+	app.SetImports([]*types.Package{infra, cached})
+
+	got := TransitiveImports(app)
+	want := []string{"example.com/cached", "example.com/domain", "example.com/infra"}
+	if len(got) != len(want) {
+		t.Fatalf("TransitiveImports() returned %d packages, want %d", len(got), len(want))
+	}
+	for i, pkg := range got {
+		if pkg.Path() != want[i] {
+			t.Errorf("TransitiveImports()[%d] = %q, want %q", i, pkg.Path(), want[i])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	mockFiles    string
+	mockPackages string
+	mockMu       sync.RWMutex
+)
+
+// SetMockFiles sets the comma-separated list of file name patterns (e.g., *_test.go,mock_*.go)
+// whose types are treated as mocks.
+func SetMockFiles(s string) {
+	mockMu.Lock()
+	mockFiles = s
+	mockMu.Unlock()
+}
+
+// SetMockPackages sets the comma-separated list of package paths whose types are treated as mocks.
+func SetMockPackages(s string) {
+	mockMu.Lock()
+	mockPackages = s
+	mockMu.Unlock()
+}
+
+// IsMockType checks if named is a test double that must not contribute to the
+// error sets of the interfaces it implements: it is annotated with
+// //goexhauerrors:mock, or matches the configured mock files or packages.
+func IsMockType(pass *analysis.Pass, named *types.Named) bool {
+	tn := named.Obj()
+	if tn.Pkg() == nil {
+		return false
+	}
+	if pass.ImportObjectFact(tn, new(facts.MockFact)) {
+		return true
+	}
+	return MatchesMockPattern(pass.Fset, tn)
+}
+
+// MatchesMockPattern checks if the type is declared in a configured mock package
+// or in a file whose base name matches a configured mock file pattern.
+func MatchesMockPattern(fset *token.FileSet, tn *types.TypeName) bool {
+	mockMu.RLock()
+	files, pkgs := mockFiles, mockPackages
+	mockMu.RUnlock()
+
+	if pkgs != "" && tn.Pkg() != nil {
+		for _, pkg := range strings.Split(pkgs, ",") {
+			if strings.TrimSpace(pkg) == tn.Pkg().Path() {
+				return true
+			}
+		}
+	}
+
+	if files != "" {
+		base := filepath.Base(fset.Position(tn.Pos()).Filename)
+		for _, pattern := range strings.Split(files, ",") {
+			if ok, _ := path.Match(strings.TrimSpace(pattern), base); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
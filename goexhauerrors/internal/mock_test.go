@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestMatchesMockPattern(t *testing.T) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("/src/example.com/repo/mock_repo.go", -1, 100)
+	pkg := types.NewPackage("example.com/repo", "repo")
+	tn := types.NewTypeName(file.Pos(10), pkg, "MockRepo", nil)
+
+	tests := []struct {
+		name     string
+		files    string
+		packages string
+		want     bool
+	}{
+		{"nothing configured", "", "", false},
+		{"file pattern match", "mock_*.go", "", true},
+		{"file pattern among several", "*_test.go, mock_*.go", "", true},
+		{"file pattern no match", "*_test.go", "", false},
+		{"package match", "", "example.com/repo", true},
+		{"package no match", "", "example.com/repo/mocks", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetMockFiles(tt.files)
+			SetMockPackages(tt.packages)
+			defer func() {
+				SetMockFiles("")
+				SetMockPackages("")
+			}()
+
+			if got := MatchesMockPattern(fset, tn); got != tt.want {
+				t.Errorf("MatchesMockPattern() with mockFiles=%q mockPackages=%q = %v, want %v",
+					tt.files, tt.packages, got, tt.want)
+			}
+		})
+	}
+}
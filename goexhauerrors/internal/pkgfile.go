@@ -0,0 +1,10 @@
+package internal
+
+import "net/url"
+
+// PackageFileName returns the name of the JSON file written for a package path, as
+// used by the error set snapshots and the implementation index.
+// Path separators are escaped so that the files of all packages live in a single directory.
+func PackageFileName(pkgPath string) string {
+	return url.QueryEscape(pkgPath) + ".json"
+}
@@ -0,0 +1,20 @@
+package internal
+
+import "testing"
+
+func TestPackageFileName(t *testing.T) {
+	tests := []struct {
+		pkgPath string
+		want    string
+	}{
+		{"fmt", "fmt.json"},
+		{"example.com/app/domain", "example.com%2Fapp%2Fdomain.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkgPath, func(t *testing.T) {
+			if got := PackageFileName(tt.pkgPath); got != tt.want {
+				t.Errorf("PackageFileName(%q) = %q, want %q", tt.pkgPath, got, tt.want)
+			}
+		})
+	}
+}
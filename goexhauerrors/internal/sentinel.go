@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"golang.org/x/tools/go/analysis"
+)
+
+// SentinelIndex holds what the facts of the current package and its dependencies
+// record about sentinels, by error key. It is built once per pass, after the
+// sentinels of the current package have been detected.
+type SentinelIndex struct {
+	pkgPath   string
+	ancestors map[string][]string // error key -> keys of the sentinels it wraps
+	imported  map[string]bool     // paths of the packages the current package imports
+	aliases   map[string]string   // error key -> key of an exported alias in an imported package
+}
+
+// NewSentinelIndex builds the sentinel index of pass from its object facts.
+// A sentinel of a package only imported indirectly may be missing from the type
+// information of the current package; its ancestors are then found on an alias of it
+// (var ErrUserNotFound = storage.ErrUserNotFound), whose facts carry the original key.
+func NewSentinelIndex(pass *analysis.Pass) *SentinelIndex {
+	idx := &SentinelIndex{
+		pkgPath:   pass.Pkg.Path(),
+		ancestors: make(map[string][]string),
+		imported:  make(map[string]bool),
+		aliases:   make(map[string]string),
+	}
+	for _, imp := range pass.Pkg.Imports() {
+		idx.imported[imp.Path()] = true
+	}
+	for _, objFact := range pass.AllObjectFacts() {
+		varObj, ok := objFact.Object.(*types.Var)
+		if !ok {
+			continue
+		}
+		switch fact := objFact.Fact.(type) {
+		case *facts.ErrorParentFact:
+			idx.ancestors[sentinelKey(pass, varObj)] = fact.Ancestors
+		case *facts.ErrorFact:
+			idx.addAlias(varObj, fact.Key())
+		}
+	}
+	return idx
+}
+
+// addAlias records varObj as an alias of the sentinel with the given key, if it is
+// an exported variable of an imported package declaring another sentinel's key.
+// Of several aliases, the one with the smallest key is kept.
+func (idx *SentinelIndex) addAlias(varObj *types.Var, key string) {
+	if !varObj.Exported() || varObj.Pkg() == nil || !idx.imported[varObj.Pkg().Path()] {
+		return
+	}
+	aliasKey := varObj.Pkg().Path() + "." + varObj.Name()
+	if aliasKey == key {
+		return
+	}
+	if existing, ok := idx.aliases[key]; !ok || aliasKey < existing {
+		idx.aliases[key] = aliasKey
+	}
+}
+
+// Ancestors returns the keys of the sentinels an error wraps, if it is a
+// hierarchical sentinel (see facts.ErrorParentFact).
+func (idx *SentinelIndex) Ancestors(errInfo facts.ErrorInfo) []string {
+	return idx.ancestors[errInfo.Key()]
+}
+
+// VisibleKey returns the key diagnostics of the current package show for errInfo.
+// A sentinel of a package the current package does not import is shown as an alias of
+// it declared in an imported package (facade.ErrNotFound for storage.ErrNotFound),
+// the name the caller can check it by. Otherwise it is the key of errInfo.
+func (idx *SentinelIndex) VisibleKey(errInfo facts.ErrorInfo) string {
+	key := errInfo.Key()
+	if errInfo.PkgPath == idx.pkgPath || idx.imported[errInfo.PkgPath] {
+		return key
+	}
+	if alias, ok := idx.aliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// sentinelKey returns the key of the sentinel varObj declares: the key of its
+// ErrorFact, which is the key of the original for an alias, or its own name.
+func sentinelKey(pass *analysis.Pass, varObj *types.Var) string {
+	var errorFact facts.ErrorFact
+	if pass.ImportObjectFact(varObj, &errorFact) {
+		return errorFact.Key()
+	}
+	return varObj.Pkg().Path() + "." + varObj.Name()
+}
@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"golang.org/x/tools/go/analysis"
+)
+
+// fakeFactPass returns a pass whose object facts are objFacts.
+func fakeFactPass(pkg *types.Package, objFacts []analysis.ObjectFact) *analysis.Pass {
+	return &analysis.Pass{
+		Pkg: pkg,
+		AllObjectFacts: func() []analysis.ObjectFact {
+			return objFacts
+		},
+		ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+			for _, objFact := range objFacts {
+				if objFact.Object != obj || reflect.TypeOf(objFact.Fact) != reflect.TypeOf(fact) {
+					continue
+				}
+				reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(objFact.Fact).Elem())
+				return true
+			}
+			return false
+		},
+	}
+}
+
+func TestSentinelIndexAncestors(t *testing.T) {
+	storage := types.NewPackage("example.com/storage", "storage")
+	facade := types.NewPackage("example.com/facade", "facade")
+	caller := types.NewPackage("example.com/caller", "caller")
+	errorType := types.Universe.Lookup("error").Type()
+
+	errNotFound := types.NewVar(token.NoPos, storage, "ErrNotFound", errorType)
+	errUserNotFound := types.NewVar(token.NoPos, storage, "ErrUserNotFound", errorType)
+	// var ErrUserNotFound = storage.ErrUserNotFound
+	alias := types.NewVar(token.NoPos, facade, "ErrUserNotFound", errorType)
+	errTimeout := types.NewVar(token.NoPos, facade, "ErrTimeout", errorType)
+
+	pass := fakeFactPass(caller, []analysis.ObjectFact{
+		{Object: errNotFound, Fact: &facts.ErrorFact{PkgPath: "example.com/storage", Name: "ErrNotFound"}},
+		{Object: errUserNotFound, Fact: &facts.ErrorFact{PkgPath: "example.com/storage", Name: "ErrUserNotFound"}},
+		{Object: alias, Fact: &facts.ErrorFact{PkgPath: "example.com/storage", Name: "ErrUserNotFound"}},
+		{Object: alias, Fact: &facts.ErrorParentFact{Ancestors: []string{"example.com/storage.ErrNotFound"}}},
+		{Object: errTimeout, Fact: &facts.ErrorFact{PkgPath: "example.com/facade", Name: "ErrTimeout"}},
+	})
+	idx := NewSentinelIndex(pass)
+
+	tests := []struct {
+		name    string
+		errInfo facts.ErrorInfo
+		want    []string
+	}{
+		{"found on an alias", facts.ErrorInfo{PkgPath: "example.com/storage", Name: "ErrUserNotFound"}, []string{"example.com/storage.ErrNotFound"}},
+		{"root sentinel", facts.ErrorInfo{PkgPath: "example.com/storage", Name: "ErrNotFound"}, nil},
+		{"sentinel without parent", facts.ErrorInfo{PkgPath: "example.com/facade", Name: "ErrTimeout"}, nil},
+		{"unknown", facts.ErrorInfo{PkgPath: "example.com/other", Name: "ErrOther"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idx.Ancestors(tt.errInfo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ancestors(%s) = %v, want %v", tt.errInfo.Key(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentinelIndexVisibleKey(t *testing.T) {
+	storage := types.NewPackage("example.com/storage", "storage")
+	facade := types.NewPackage("example.com/facade", "facade")
+	api := types.NewPackage("example.com/api", "api")
+	caller := types.NewPackage("example.com/caller", "caller")
+	caller.SetImports([]*types.Package{facade, api})
+	errorType := types.Universe.Lookup("error").Type()
+
+	storageFact := &facts.ErrorFact{PkgPath: "example.com/storage", Name: "ErrNotFound"}
+	pass := fakeFactPass(caller, []analysis.ObjectFact{
+		{Object: types.NewVar(token.NoPos, storage, "ErrNotFound", errorType), Fact: storageFact},
+		{Object: types.NewVar(token.NoPos, facade, "ErrNotFound", errorType), Fact: storageFact},
+		{Object: types.NewVar(token.NoPos, api, "ErrMissing", errorType), Fact: storageFact},
+		{Object: types.NewVar(token.NoPos, facade, "errHidden", errorType), Fact: &facts.ErrorFact{PkgPath: "example.com/storage", Name: "ErrGone"}},
+		{Object: types.NewVar(token.NoPos, facade, "ErrTimeout", errorType), Fact: &facts.ErrorFact{PkgPath: "example.com/facade", Name: "ErrTimeout"}},
+	})
+	idx := NewSentinelIndex(pass)
+
+	tests := []struct {
+		name    string
+		errInfo facts.ErrorInfo
+		want    string
+	}{
+		{"alias with the smallest key", facts.ErrorInfo{PkgPath: "example.com/storage", Name: "ErrNotFound"}, "example.com/api.ErrMissing"},
+		{"unexported alias", facts.ErrorInfo{PkgPath: "example.com/storage", Name: "ErrGone"}, "example.com/storage.ErrGone"},
+		{"imported package", facts.ErrorInfo{PkgPath: "example.com/facade", Name: "ErrTimeout"}, "example.com/facade.ErrTimeout"},
+		{"current package", facts.ErrorInfo{PkgPath: "example.com/caller", Name: "errLocal"}, "example.com/caller.errLocal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idx.VisibleKey(tt.errInfo); got != tt.want {
+				t.Errorf("VisibleKey(%s) = %q, want %q", tt.errInfo.Key(), got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package ssaanalysis
+
+import (
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// InvokeSites maps the opening parenthesis of every interface method call in the
+// package to its SSA call, so that AST-based analysis can look up the receiver value.
+func InvokeSites(ssaResult *buildssa.SSA) map[token.Pos]*ssa.CallCommon {
+	sites := make(map[token.Pos]*ssa.CallCommon)
+	for _, fn := range ssaResult.SrcFuncs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok || !call.Common().IsInvoke() {
+					continue
+				}
+				sites[call.Common().Pos()] = call.Common()
+			}
+		}
+	}
+	return sites
+}
+
+// ReceiverTypes returns the concrete types the receiver of an interface method call
+// can have, when every value reaching the receiver can be traced to a conversion of
+// a concrete type (e.g. var r Repository = &UserRepo{}). It follows MakeInterface,
+// ChangeInterface, Phi nodes and the results of static calls to functions of the
+// same package (constructors). It returns nil if any source of the receiver is
+// unknown, such as a parameter, a field or a call into another package; callers
+// then fall back to all implementations of the interface.
+// The result is ordered by package path and type name.
+func ReceiverTypes(call *ssa.CallCommon) []*types.Named {
+	if !call.IsInvoke() {
+		return nil
+	}
+	seen := make(map[*types.Named]bool)
+	if !collectReceiverTypes(call.Value, make(map[ssa.Value]bool), 0, seen) || len(seen) == 0 {
+		return nil
+	}
+
+	result := make([]*types.Named, 0, len(seen))
+	for named := range seen {
+		result = append(result, named)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		pi, pj := result[i].Obj().Pkg().Path(), result[j].Obj().Pkg().Path()
+		if pi != pj {
+			return pi < pj
+		}
+		return result[i].Obj().Name() < result[j].Obj().Name()
+	})
+	return result
+}
+
+// collectReceiverTypes adds the concrete types val can hold to seen.
+// It returns false if a source of val cannot be traced.
+func collectReceiverTypes(val ssa.Value, visited map[ssa.Value]bool, depth int, seen map[*types.Named]bool) bool {
+	if depth > maxTraceDepth {
+		return false
+	}
+	if visited[val] {
+		return true
+	}
+	visited[val] = true
+
+	switch v := val.(type) {
+	case *ssa.MakeInterface:
+		named := concreteNamed(v.X.Type())
+		if named == nil {
+			return false
+		}
+		seen[named] = true
+		return true
+
+	case *ssa.ChangeInterface:
+		return collectReceiverTypes(v.X, visited, depth+1, seen)
+
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if !collectReceiverTypes(edge, visited, depth+1, seen) {
+				return false
+			}
+		}
+		return true
+
+	case *ssa.Const:
+		// A nil interface has no implementation to call
+		return v.IsNil()
+
+	case *ssa.Call:
+		return collectCalleeResultTypes(v, 0, visited, depth, seen)
+
+	case *ssa.Extract:
+		if call, ok := v.Tuple.(*ssa.Call); ok {
+			return collectCalleeResultTypes(call, v.Index, visited, depth, seen)
+		}
+	}
+	return false
+}
+
+// collectCalleeResultTypes traces result index of a static call into the returns of
+// the callee. Only callees of the package under analysis have a body to trace.
+func collectCalleeResultTypes(call *ssa.Call, index int, visited map[ssa.Value]bool, depth int, seen map[*types.Named]bool) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || len(callee.Blocks) == 0 || callee.Pkg != call.Parent().Pkg {
+		return false
+	}
+	for _, block := range callee.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok || index >= len(ret.Results) {
+			continue
+		}
+		if !collectReceiverTypes(ret.Results[index], visited, depth+1, seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// concreteNamed returns the named type of a concrete value or pointer to it.
+func concreteNamed(t types.Type) *types.Named {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, _ := types.Unalias(t).(*types.Named)
+	return named
+}
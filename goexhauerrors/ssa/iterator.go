@@ -0,0 +1,204 @@
+package ssaanalysis
+
+import (
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/ssa"
+)
+
+// TraceIteratorYields returns the errors yielded by the range-over-func iterator fn
+// returns (func (r *Repo) All() iter.Seq2[User, error]), or by fn itself if it is an
+// iterator (func (r *Repo) Rows(yield func(Row, error) bool)).
+func (a *Analyzer) TraceIteratorYields(fn *types.Func) []facts.ErrorInfo {
+	ssaFn := a.FindSSAFunction(fn)
+	if ssaFn == nil {
+		return nil
+	}
+	visited := make(map[ssa.Value]bool)
+
+	sig := fn.Type().(*types.Signature)
+	if index := internal.IteratorYieldErrorIndex(sig); index >= 0 {
+		return a.deduplicateErrors(a.traceYieldCalls(ssaFn, index, visited))
+	}
+
+	var errs []facts.ErrorInfo
+	for _, block := range ssaFn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		for i, result := range ret.Results {
+			if index := internal.IteratorYieldErrorIndex(sig.Results().At(i).Type()); index >= 0 {
+				errs = append(errs, a.traceIteratorValue(result, index, visited, 0)...)
+			}
+		}
+	}
+	return a.deduplicateErrors(errs)
+}
+
+// traceIteratorValue traces an iterator value to the function literals it is made of,
+// or to calls of other functions returning iterators.
+func (a *Analyzer) traceIteratorValue(val ssa.Value, index int, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	if val == nil || visited[val] || depth > maxTraceDepth {
+		return nil
+	}
+	visited[val] = true
+
+	switch v := val.(type) {
+	case *ssa.MakeClosure:
+		if fn, ok := v.Fn.(*ssa.Function); ok {
+			// Method values (range r.Rows) are closures of a wrapper bound to the method
+			if errs, ok := a.lookupIteratorFact(fn); ok {
+				return errs
+			}
+			return a.traceYieldCalls(fn, index, visited)
+		}
+
+	case *ssa.Function:
+		// A declared function, or a function literal without captured variables
+		if errs, ok := a.lookupIteratorFact(v); ok {
+			return errs
+		}
+		return a.traceYieldCalls(v, index, visited)
+
+	case *ssa.ChangeType:
+		// func literal converted to iter.Seq2
+		return a.traceIteratorValue(v.X, index, visited, depth+1)
+
+	case *ssa.Phi:
+		var errs []facts.ErrorInfo
+		for _, edge := range v.Edges {
+			errs = append(errs, a.traceIteratorValue(edge, index, visited, depth+1)...)
+		}
+		return errs
+
+	case *ssa.Call:
+		if callee := v.Call.StaticCallee(); callee != nil {
+			errs, _ := a.lookupIteratorFact(callee)
+			return errs
+		}
+	}
+	return nil
+}
+
+// lookupIteratorFact returns the IteratorErrorsFact of the declared function behind fn,
+// computed for this package or imported.
+func (a *Analyzer) lookupIteratorFact(fn *ssa.Function) ([]facts.ErrorInfo, bool) {
+	obj, ok := fn.Object().(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	if localFact, ok := a.LocalIteratorFacts[obj]; ok {
+		return localFact.Errors, true
+	}
+	var iterFact facts.IteratorErrorsFact
+	if a.pass.ImportObjectFact(obj, &iterFact) {
+		return iterFact.Errors, true
+	}
+	return nil, false
+}
+
+// traceYieldCalls returns the errors passed as argument index to yield, the last
+// parameter of the iterator function fn.
+func (a *Analyzer) traceYieldCalls(fn *ssa.Function, index int, visited map[ssa.Value]bool) []facts.ErrorInfo {
+	if len(fn.Params) == 0 {
+		return nil
+	}
+	yield := fn.Params[len(fn.Params)-1]
+
+	var errs []facts.ErrorInfo
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || call.Call.Value != yield || index >= len(call.Call.Args) {
+				continue
+			}
+			errs = append(errs, a.traceValueToErrors(call.Call.Args[index], visited, 0)...)
+		}
+	}
+	return errs
+}
+
+// rangeFuncYield is the Synthetic description of the function SSA builds for the body
+// of a range-over-func loop.
+const rangeFuncYield = "range-over-func yield"
+
+// getErrorsFromRangeFuncParam returns the errors the iterator of a range-over-func loop
+// yields to the range variable param of the loop body.
+func (a *Analyzer) getErrorsFromRangeFuncParam(param *ssa.Parameter, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	body := param.Parent()
+	if body.Synthetic != rangeFuncYield || body.Referrers() == nil {
+		return nil
+	}
+	index := -1
+	for i, p := range body.Params {
+		if p == param {
+			index = i
+		}
+	}
+
+	// The body is passed to the iterator directly, or as a closure over the loop state
+	yieldValues := []ssa.Value{body}
+	for _, ref := range *body.Referrers() {
+		if closure, ok := ref.(*ssa.MakeClosure); ok {
+			yieldValues = append(yieldValues, closure)
+		}
+	}
+
+	var errs []facts.ErrorInfo
+	for _, yield := range yieldValues {
+		if yield.Referrers() == nil {
+			continue
+		}
+		for _, ref := range *yield.Referrers() {
+			call, ok := ref.(*ssa.Call)
+			if !ok || len(call.Call.Args) != 1 || call.Call.Args[0] != yield {
+				continue
+			}
+			if internal.IteratorYieldErrorIndex(call.Call.Value.Type()) != index {
+				continue
+			}
+			errs = append(errs, a.traceIteratorValue(call.Call.Value, index, visited, depth+1)...)
+		}
+	}
+	return errs
+}
+
+// getErrorsFromRangeFuncStores returns the errors stored to the local alloc by the bodies
+// of range-over-func loops. A return statement inside such a body stores its results
+// to the slots of the enclosing function, which then returns them after the loop.
+func (a *Analyzer) getErrorsFromRangeFuncStores(alloc ssa.Value, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	if depth > maxTraceDepth || alloc.Referrers() == nil {
+		return nil
+	}
+	var errs []facts.ErrorInfo
+	for _, ref := range *alloc.Referrers() {
+		closure, ok := ref.(*ssa.MakeClosure)
+		if !ok {
+			continue
+		}
+		body, ok := closure.Fn.(*ssa.Function)
+		if !ok || body.Synthetic != rangeFuncYield {
+			continue
+		}
+		for i, binding := range closure.Bindings {
+			if binding != alloc || i >= len(body.FreeVars) {
+				continue
+			}
+			freeVar := body.FreeVars[i]
+			if freeVar.Referrers() == nil {
+				continue
+			}
+			for _, fvRef := range *freeVar.Referrers() {
+				if store, ok := fvRef.(*ssa.Store); ok && store.Addr == freeVar {
+					errs = append(errs, a.traceValueToErrors(store.Val, visited, depth+1)...)
+				}
+			}
+			// Nested range-over-func loops capture the slot again
+			errs = append(errs, a.getErrorsFromRangeFuncStores(freeVar, visited, depth+1)...)
+		}
+	}
+	return errs
+}
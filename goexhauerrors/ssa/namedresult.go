@@ -0,0 +1,249 @@
+package ssaanalysis
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/ssa"
+)
+
+// ReplacesReturnedErrors reports whether deferred closures of fn replace every error
+// its return statements set, as in
+//
+//	defer func() { if err != nil { err = ErrSave } }()
+//
+// The errors of the return operands then never reach the caller.
+func (a *Analyzer) ReplacesReturnedErrors(fn *types.Func, errorPositions []int) bool {
+	ssaFn := a.FindSSAFunction(fn)
+	if ssaFn == nil || len(errorPositions) == 0 {
+		return false
+	}
+	found := false
+	for _, block := range ssaFn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		for _, pos := range errorPositions {
+			if pos >= len(ret.Results) {
+				return false
+			}
+			load, ok := ret.Results[pos].(*ssa.UnOp)
+			if !ok || load.Op != token.MUL {
+				return false
+			}
+			alloc, ok := load.X.(*ssa.Alloc)
+			if !ok || !a.replacedByDefer(alloc) {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// getErrorsFromCapturedVar returns the errors stored to alloc, a local error variable
+// captured by closures, such as the named result of a function with deferred calls.
+// Stores made by closures are added to the stores of the function itself, unless a
+// deferred closure replaces the error once it is set.
+func (a *Analyzer) getErrorsFromCapturedVar(alloc *ssa.Alloc, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	if depth > maxTraceDepth || !alloc.Heap || !internal.IsErrorType(alloc.Type().(*types.Pointer).Elem()) {
+		return nil
+	}
+
+	var errs []facts.ErrorInfo
+	replaced := false
+	for _, capture := range closureCaptures(alloc) {
+		errs = append(errs, a.getErrorsFromStores(capture.freeVar, visited, depth+1)...)
+		if capture.deferred && rewritesSetError(capture.body, capture.freeVar) {
+			replaced = true
+		}
+	}
+	if replaced {
+		return errs
+	}
+	return append(errs, a.getErrorsFromStores(alloc, visited, depth+1)...)
+}
+
+// getErrorsFromFreeVar returns the errors of the variable a closure captures as fv,
+// as stored by the enclosing function.
+func (a *Analyzer) getErrorsFromFreeVar(fv *ssa.FreeVar, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	fn := fv.Parent()
+	if fn.Referrers() == nil {
+		return nil
+	}
+	index := -1
+	for i, v := range fn.FreeVars {
+		if v == fv {
+			index = i
+		}
+	}
+
+	var errs []facts.ErrorInfo
+	for _, ref := range *fn.Referrers() {
+		closure, ok := ref.(*ssa.MakeClosure)
+		if !ok || index < 0 || index >= len(closure.Bindings) {
+			continue
+		}
+		binding := closure.Bindings[index]
+		alloc, ok := binding.(*ssa.Alloc)
+		if !ok {
+			errs = append(errs, a.traceValueToErrors(binding, visited, depth+1)...)
+			continue
+		}
+		// Only the stores of the enclosing function: the closures storing to the
+		// variable are the ones being traced
+		seen := map[ssa.Value]bool{alloc: true}
+		errs = append(errs, a.getErrorsFromStores(alloc, seen, depth+1)...)
+	}
+	return errs
+}
+
+// getErrorsFromStores returns the errors of the values stored to addr. Values made by
+// wrapper calls (err = fmt.Errorf("save: %w", err)) carry the errors they wrap.
+func (a *Analyzer) getErrorsFromStores(addr ssa.Value, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	if addr.Referrers() == nil {
+		return nil
+	}
+	var errs []facts.ErrorInfo
+	for _, ref := range *addr.Referrers() {
+		store, ok := ref.(*ssa.Store)
+		if !ok || store.Addr != addr {
+			continue
+		}
+		errs = append(errs, a.traceValueToErrors(store.Val, visited, depth)...)
+		if call, ok := store.Val.(*ssa.Call); ok {
+			args, w := getWrappedArgs(call)
+			for _, arg := range args {
+				argErrs := a.traceValueToErrors(arg, visited, depth+1)
+				for i := range argErrs {
+					argErrs[i] = w.Carry(argErrs[i])
+				}
+				errs = append(errs, argErrs...)
+			}
+		}
+	}
+	return errs
+}
+
+// replacedByDefer reports whether a deferred closure replaces the error stored to alloc.
+func (a *Analyzer) replacedByDefer(alloc *ssa.Alloc) bool {
+	for _, capture := range closureCaptures(alloc) {
+		if capture.deferred && rewritesSetError(capture.body, capture.freeVar) {
+			return true
+		}
+	}
+	return false
+}
+
+// closureCapture is a closure capturing a local variable.
+type closureCapture struct {
+	body     *ssa.Function
+	freeVar  *ssa.FreeVar
+	deferred bool
+}
+
+// closureCaptures returns the closures capturing alloc, except the bodies of
+// range-over-func loops, whose stores are traced by getErrorsFromRangeFuncStores.
+func closureCaptures(alloc *ssa.Alloc) []closureCapture {
+	if alloc.Referrers() == nil {
+		return nil
+	}
+	var captures []closureCapture
+	for _, ref := range *alloc.Referrers() {
+		closure, ok := ref.(*ssa.MakeClosure)
+		if !ok {
+			continue
+		}
+		body, ok := closure.Fn.(*ssa.Function)
+		if !ok || body.Synthetic == rangeFuncYield {
+			continue
+		}
+		for i, binding := range closure.Bindings {
+			if binding != alloc || i >= len(body.FreeVars) {
+				continue
+			}
+			captures = append(captures, closureCapture{
+				body:     body,
+				freeVar:  body.FreeVars[i],
+				deferred: isDeferred(closure),
+			})
+		}
+	}
+	return captures
+}
+
+// isDeferred reports whether closure is called by a defer statement.
+func isDeferred(closure *ssa.MakeClosure) bool {
+	for _, ref := range *closure.Referrers() {
+		if d, ok := ref.(*ssa.Defer); ok && d.Call.Value == closure {
+			return true
+		}
+	}
+	return false
+}
+
+// rewritesSetError reports whether the closure body starts with if err != nil and
+// assigns err a new value in that branch, where err is the captured variable fv.
+// The new value may wrap the old one, but must not be built from it otherwise
+// (err = errors.Join(err, closeErr) keeps the old errors without wrapping them).
+func rewritesSetError(body *ssa.Function, fv *ssa.FreeVar) bool {
+	if len(body.Blocks) == 0 {
+		return false
+	}
+	entry := body.Blocks[0]
+	ifInstr, ok := entry.Instrs[len(entry.Instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	cond, ok := ifInstr.Cond.(*ssa.BinOp)
+	if !ok || cond.Op != token.NEQ || !isNilConst(cond.Y) || !loadsFrom(cond.X, fv) {
+		return false
+	}
+
+	for _, instr := range entry.Succs[0].Instrs {
+		store, ok := instr.(*ssa.Store)
+		if !ok || store.Addr != fv {
+			continue
+		}
+		call, ok := store.Val.(*ssa.Call)
+		if !ok {
+			return true
+		}
+		if args, _ := getWrappedArgs(call); args != nil {
+			return true
+		}
+		for _, arg := range call.Call.Args {
+			if slice, ok := arg.(*ssa.Slice); ok {
+				for _, elem := range extractSliceElements(slice) {
+					if loadsFrom(elem, fv) {
+						return false
+					}
+				}
+			}
+			if loadsFrom(arg, fv) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// loadsFrom reports whether val is a load of addr, possibly converted to another interface.
+func loadsFrom(val ssa.Value, addr ssa.Value) bool {
+	switch v := val.(type) {
+	case *ssa.UnOp:
+		return v.Op == token.MUL && v.X == addr
+	case *ssa.ChangeInterface:
+		return loadsFrom(v.X, addr)
+	}
+	return false
+}
+
+func isNilConst(val ssa.Value) bool {
+	c, ok := val.(*ssa.Const)
+	return ok && c.IsNil()
+}
@@ -4,6 +4,7 @@ import (
 	"go/token"
 	"go/types"
 
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/callsite"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/detector"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
@@ -14,13 +15,17 @@ import (
 
 // Analyzer provides SSA-based dataflow analysis for tracking error values.
 type Analyzer struct {
-	pass                *analysis.Pass
-	ssaResult           *buildssa.SSA
-	LocalErrs           *detector.LocalErrors
-	LocalFacts          map[*types.Func]*facts.FunctionErrorsFact
-	LocalParamFlowFacts map[*types.Func]*facts.ParameterFlowFact
-	LocalCallFlowFacts  map[*types.Func]*facts.FunctionParamCallFlowFact
-	InterfaceImpls      *internal.InterfaceImplementations
+	pass                 *analysis.Pass
+	ssaResult            *buildssa.SSA
+	LocalErrs            *detector.LocalErrors
+	LocalFacts           map[*types.Func]*facts.FunctionErrorsFact
+	LocalParamFlowFacts  map[*types.Func]*facts.ParameterFlowFact
+	LocalCallFlowFacts   map[*types.Func]*facts.FunctionParamCallFlowFact
+	InterfaceImpls       *internal.InterfaceImplementations
+	LocalIteratorFacts   map[*types.Func]*facts.IteratorErrorsFact   // Iterators of this package analyzed so far
+	LocalFieldFacts      map[*types.Var]*facts.FunctionErrorsFact    // Function-typed fields of this package analyzed so far
+	LocalFuncResultFacts map[*types.Func]*facts.FuncResultErrorsFact // Functions of this package returning function values
+	CallSites            callsite.Index                              // Callees of dynamic calls, in whole-program mode
 }
 
 // NewAnalyzer creates a new SSA analyzer.
@@ -107,6 +112,9 @@ func (a *Analyzer) traceValueToErrors(val ssa.Value, visited map[ssa.Value]bool,
 		// Also resolve errors via ParameterFlowFact
 		paramFlowErrs := a.resolveParameterFlowErrors(v, visited, depth)
 		errs = append(errs, paramFlowErrs...)
+
+		// In whole-program mode, dynamic calls return the errors of their call graph callees
+		errs = append(errs, a.CallSites.Lookup(a.pass.Fset.Position(v.Pos()))...)
 		// Do NOT trace further into the call - this avoids picking up internal types
 
 	case *ssa.Extract:
@@ -124,6 +132,8 @@ func (a *Analyzer) traceValueToErrors(val ssa.Value, visited map[ssa.Value]bool,
 		// Converting concrete type to interface
 		// Only add if it's a known custom error type (local or with fact)
 		errs = append(errs, a.getErrorsFromMakeInterface(v)...)
+		// Wrapper types (with UnwrapFact) also carry the errors stored in their Unwrap fields
+		errs = append(errs, a.getErrorsFromUnwrapFields(v.X, visited, depth)...)
 		// Do NOT trace v.X further to avoid discovering internal types
 
 	case *ssa.UnOp:
@@ -134,6 +144,10 @@ func (a *Analyzer) traceValueToErrors(val ssa.Value, visited map[ssa.Value]bool,
 	case *ssa.Alloc:
 		// Allocation - only add if it's a known custom error type
 		errs = append(errs, a.getErrorsFromAlloc(v)...)
+		// The result slot of a function returning from inside a range-over-func loop
+		errs = append(errs, a.getErrorsFromRangeFuncStores(v, visited, depth)...)
+		// A variable captured by closures, such as a named result rewritten by a deferred call
+		errs = append(errs, a.getErrorsFromCapturedVar(v, visited, depth)...)
 
 	case *ssa.Global:
 		// Global variable - check if it's a known error
@@ -144,7 +158,13 @@ func (a *Analyzer) traceValueToErrors(val ssa.Value, visited map[ssa.Value]bool,
 		errs = append(errs, a.traceValueToErrors(v.X, visited, depth+1)...)
 
 	case *ssa.Parameter:
-		// Function parameter - can't trace statically (known limitation)
+		// Function parameter - can't trace statically (known limitation),
+		// except for the range variables of a range-over-func loop body
+		errs = append(errs, a.getErrorsFromRangeFuncParam(v, visited, depth)...)
+
+	case *ssa.FreeVar:
+		// Variable captured by a closure - trace the stores of the enclosing function
+		errs = append(errs, a.getErrorsFromFreeVar(v, visited, depth)...)
 
 	case *ssa.FieldAddr:
 		// Field address - don't trace (known limitation)
@@ -181,7 +201,28 @@ func (a *Analyzer) getErrorsFromCall(call *ssa.Call, visited map[ssa.Value]bool,
 
 	callee, typesFunc := resolveStaticCallee(call)
 	if callee == nil {
-		return nil
+		// An element of a slice built in this function (for _, fn := range []func() error{f, g})
+		if elems := sliceElements(elementSlice(call.Call.Value)); elems != nil {
+			var errs []facts.ErrorInfo
+			for _, elem := range elems {
+				errs = append(errs, a.getErrorsFromFunctionValue(elem, visited, depth+1)...)
+			}
+			return errs
+		}
+		// A function value loaded from a struct field (st.onSave(), r.handlers[path]())
+		return a.getErrorsFromFuncField(call.Call.Value)
+	}
+
+	// A root-cause function (errors.Cause) returns the errors of its argument, unwrapped
+	// if it reaches them, whatever its implementation
+	if args, w := getWrappedArgs(call); w.Unwraps {
+		var errs []facts.ErrorInfo
+		for _, arg := range args {
+			for _, errInfo := range a.traceValueToErrors(arg, visited, depth+1) {
+				errs = append(errs, w.Carry(errInfo))
+			}
+		}
+		return errs
 	}
 
 	var errs []facts.ErrorInfo
@@ -202,18 +243,41 @@ func (a *Analyzer) getErrorsFromCall(call *ssa.Call, visited map[ssa.Value]bool,
 // getErrorsFromInvoke extracts error information from an interface method call.
 // It collects errors from all known implementations of the interface method,
 // and also resolves ParameterFlowFact to trace errors through parameters.
+// When the receiver can only hold specific concrete types, only their methods are used.
+// An ErrorContractFact replaces the implementations' own errors only: errors passed in
+// through arguments still flow through the method, as the contract cannot name them.
 func (a *Analyzer) getErrorsFromInvoke(call *ssa.Call, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
 	ifaceMethod := call.Call.Method
 	if ifaceMethod == nil {
 		return nil
 	}
 
+	// A method call on a parameter of type parameter type is recorded as a call flow
+	// and resolved with the type argument at each call site
+	if param, ok := call.Call.Value.(*ssa.Parameter); ok {
+		if _, ok := param.Type().(*types.TypeParam); ok {
+			return nil
+		}
+	}
+
+	if concreteTypes := ReceiverTypes(&call.Call); concreteTypes != nil {
+		if errs, ok := a.getErrorsFromDevirtualizedInvoke(call, concreteTypes, visited, depth); ok {
+			return errs
+		}
+	}
+
 	var allErrors []facts.ErrorInfo
 
 	// Check InterfaceMethodFact for this method
 	var ifaceFact facts.InterfaceMethodFact
 	hasIfaceFact := a.pass.ImportObjectFact(ifaceMethod, &ifaceFact)
-	if hasIfaceFact {
+	var contract facts.ErrorContractFact
+	hasContract := a.pass.ImportObjectFact(ifaceMethod, &contract)
+	if hasContract {
+		// A declared contract takes precedence over the implementations' own errors;
+		// the parameter and call flows below still apply
+		allErrors = append(allErrors, contract.Errors...)
+	} else if hasIfaceFact {
 		allErrors = append(allErrors, ifaceFact.Errors...)
 	}
 
@@ -241,11 +305,11 @@ func (a *Analyzer) getErrorsFromInvoke(call *ssa.Call, visited map[ssa.Value]boo
 		return a.deduplicateErrors(allErrors)
 	}
 
-	// Find all implementations and collect their errors
+	// Find all implementations and collect their errors, unless a contract replaces them
 	implementingTypes := a.InterfaceImpls.GetImplementingTypes(ifaceType)
 	for _, concreteType := range implementingTypes {
 		method := internal.FindMethodImplementation(concreteType, ifaceMethod)
-		if method == nil {
+		if method == nil || hasContract {
 			continue
 		}
 
@@ -302,6 +366,38 @@ func (a *Analyzer) getErrorsFromInvoke(call *ssa.Call, visited map[ssa.Value]boo
 	return a.deduplicateErrors(allErrors)
 }
 
+// getErrorsFromDevirtualizedInvoke extracts error information from an interface method
+// call whose receiver is known to hold one of concreteTypes. Each concrete method is
+// resolved like a direct call and the results are merged.
+// It returns false if a concrete type does not implement the method or only forwards it
+// to an embedded interface, whose errors depend on the value it holds, or if a concrete
+// method has no facts.
+func (a *Analyzer) getErrorsFromDevirtualizedInvoke(call *ssa.Call, concreteTypes []*types.Named, visited map[ssa.Value]bool, depth int) ([]facts.ErrorInfo, bool) {
+	var allErrors []facts.ErrorInfo
+	for _, concreteType := range concreteTypes {
+		method := internal.FindMethodImplementation(concreteType, call.Call.Method)
+		if method == nil || internal.IsAbstractMethod(method) {
+			return nil, false
+		}
+		// Facts are attached to the generic method, not to (*GenRepo[int]).Get
+		method = method.Origin()
+		errs := a.lookupFunctionErrorsFact(method)
+		flowFact := a.lookupParameterFlowFact(method)
+		callFlowFact := a.lookupCallFlowFact(method)
+		if errs == nil && flowFact == nil && callFlowFact == nil {
+			return nil, false
+		}
+		allErrors = append(allErrors, errs...)
+		if flowFact != nil {
+			allErrors = append(allErrors, a.resolveParameterFlowErrorsForInvoke(call, flowFact, visited, depth)...)
+		}
+		if callFlowFact != nil {
+			allErrors = append(allErrors, a.resolveFunctionParamCallFlowForInvoke(call, callFlowFact, visited, depth)...)
+		}
+	}
+	return a.deduplicateErrors(allErrors), true
+}
+
 // resolveParameterFlowErrorsForInvoke resolves concrete errors passed as arguments
 // to an interface method call (invoke mode) based on ParameterFlowFact.
 // In invoke mode, call.Call.Args contains only method arguments (no receiver).
@@ -320,6 +416,12 @@ func (a *Analyzer) resolveParameterFlowErrorsForInvoke(call *ssa.Call, flowFact
 			if flow.Wrapped {
 				argErrs[i].Wrapped = true
 			}
+			if flow.CauseHidden {
+				argErrs[i].CauseHidden = true
+			}
+			if argErrs[i].WrappedBy == "" {
+				argErrs[i].WrappedBy = flow.WrappedBy
+			}
 		}
 		errs = append(errs, argErrs...)
 	}
@@ -352,6 +454,12 @@ func (a *Analyzer) resolveParameterFlowErrorsForStaticCall(call *ssa.Call, flowF
 			if flow.Wrapped {
 				argErrs[i].Wrapped = true
 			}
+			if flow.CauseHidden {
+				argErrs[i].CauseHidden = true
+			}
+			if argErrs[i].WrappedBy == "" {
+				argErrs[i].WrappedBy = flow.WrappedBy
+			}
 		}
 		errs = append(errs, argErrs...)
 	}
@@ -372,11 +480,14 @@ func (a *Analyzer) resolveFunctionParamCallFlowForInvoke(call *ssa.Call, callFlo
 			continue
 		}
 
-		argErrs := a.getErrorsFromFunctionValue(args[argIdx], visited, depth+1)
+		argErrs := a.getErrorsFromFlowArg(args[argIdx], flow, visited, depth)
 		for i := range argErrs {
 			if flow.Wrapped {
 				argErrs[i].Wrapped = true
 			}
+			if flow.CauseHidden {
+				argErrs[i].CauseHidden = true
+			}
 		}
 		errs = append(errs, argErrs...)
 	}
@@ -405,11 +516,14 @@ func (a *Analyzer) resolveFunctionParamCallFlowForStaticCall(call *ssa.Call, cal
 			continue
 		}
 
-		argErrs := a.getErrorsFromFunctionValue(args[argIdx], visited, depth+1)
+		argErrs := a.getErrorsFromFlowArg(args[argIdx], flow, visited, depth)
 		for i := range argErrs {
 			if flow.Wrapped {
 				argErrs[i].Wrapped = true
 			}
+			if flow.CauseHidden {
+				argErrs[i].CauseHidden = true
+			}
 		}
 		errs = append(errs, argErrs...)
 	}
@@ -417,8 +531,27 @@ func (a *Analyzer) resolveFunctionParamCallFlowForStaticCall(call *ssa.Call, cal
 	return errs
 }
 
+// getErrorsFromFlowArg extracts errors from the argument of a call flow: the function
+// value itself, the union over the elements of a variadic or slice argument, or the
+// method of the argument's type for a method flow.
+func (a *Analyzer) getErrorsFromFlowArg(arg ssa.Value, flow facts.FunctionParamCallFlowInfo, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	if flow.Method != "" {
+		return a.getErrorsFromMethodFlowArg(arg, flow.Method)
+	}
+	if !flow.Elements {
+		return a.getErrorsFromFunctionValue(arg, visited, depth+1)
+	}
+	var errs []facts.ErrorInfo
+	for _, elem := range sliceElements(arg) {
+		errs = append(errs, a.getErrorsFromFunctionValue(elem, visited, depth+1)...)
+	}
+	return errs
+}
+
 // getErrorsFromFunctionValue extracts errors from a function-typed SSA value.
-// It handles function references (MakeClosure, named functions) by looking up their FunctionErrorsFact.
+// It handles function references (MakeClosure, named functions) by looking up their FunctionErrorsFact,
+// anonymous functions by tracing their returns, and function values returned by a call
+// (New(WithPort(80))) by the FuncResultErrorsFact of the callee.
 func (a *Analyzer) getErrorsFromFunctionValue(val ssa.Value, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
 	if val == nil || visited[val] || depth > maxTraceDepth {
 		return nil
@@ -427,16 +560,20 @@ func (a *Analyzer) getErrorsFromFunctionValue(val ssa.Value, visited map[ssa.Val
 
 	switch v := val.(type) {
 	case *ssa.MakeClosure:
-		// Closure - try to look up FunctionErrorsFact of the underlying function.
-		// Note: Anonymous closures typically have fn.Object() == nil, so this
-		// won't resolve them. Inline closures are handled by the AST path instead.
+		// Closure - look up the FunctionErrorsFact of the underlying function
 		if fn, ok := v.Fn.(*ssa.Function); ok {
-			return a.getFunctionErrorsFact(fn)
+			return a.getErrorsFromSSAFunction(fn, visited, depth)
 		}
 
 	case *ssa.Function:
 		// Direct function reference
-		return a.getFunctionErrorsFact(v)
+		return a.getErrorsFromSSAFunction(v, visited, depth)
+
+	case *ssa.Call:
+		// Function value returned by a call
+		if _, typesFunc := resolveStaticCallee(v); typesFunc != nil {
+			return a.lookupFuncResultErrorsFact(typesFunc)
+		}
 
 	case *ssa.Phi:
 		// Merge from different branches
@@ -453,6 +590,42 @@ func (a *Analyzer) getErrorsFromFunctionValue(val ssa.Value, visited map[ssa.Val
 	return nil
 }
 
+// getErrorsFromSSAFunction returns the errors of calling fn: its FunctionErrorsFact, or
+// for an anonymous function, which has no facts, the errors traced from its returns.
+func (a *Analyzer) getErrorsFromSSAFunction(fn *ssa.Function, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	if fn.Object() != nil || fn.Parent() == nil {
+		return a.getFunctionErrorsFact(fn)
+	}
+	errorPositions := internal.FindErrorReturnPositions(fn.Signature)
+	var errs []facts.ErrorInfo
+	for _, block := range fn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		for _, pos := range errorPositions {
+			if pos < len(ret.Results) {
+				errs = append(errs, a.traceValueToErrors(ret.Results[pos], visited, depth+1)...)
+			}
+		}
+	}
+	return errs
+}
+
+// lookupFuncResultErrorsFact returns the errors of calling the function value fn returns,
+// checking local facts first, then imported facts.
+func (a *Analyzer) lookupFuncResultErrorsFact(fn *types.Func) []facts.ErrorInfo {
+	var errs []facts.ErrorInfo
+	if localFact, ok := a.LocalFuncResultFacts[fn]; ok {
+		errs = append(errs, localFact.Errors...)
+	}
+	var imported facts.FuncResultErrorsFact
+	if a.pass.ImportObjectFact(fn, &imported) {
+		errs = append(errs, imported.Errors...)
+	}
+	return errs
+}
+
 // getFunctionErrorsFact retrieves the FunctionErrorsFact for an SSA function.
 func (a *Analyzer) getFunctionErrorsFact(fn *ssa.Function) []facts.ErrorInfo {
 	obj := fn.Object()
@@ -480,6 +653,51 @@ func (a *Analyzer) lookupFunctionErrorsFact(fn *types.Func) []facts.ErrorInfo {
 	return errs
 }
 
+// getErrorsFromFuncField returns the errors of calling the function value fn, if it is
+// loaded from a function-typed struct field, or an element of one, that carries a
+// FunctionErrorsFact.
+func (a *Analyzer) getErrorsFromFuncField(fn ssa.Value) []facts.ErrorInfo {
+	field := funcValueField(fn)
+	if field == nil {
+		return nil
+	}
+	var errs []facts.ErrorInfo
+	if localFact, ok := a.LocalFieldFacts[field]; ok {
+		errs = append(errs, localFact.Errors...)
+	}
+	var imported facts.FunctionErrorsFact
+	if a.pass.ImportObjectFact(field, &imported) {
+		errs = append(errs, imported.Errors...)
+	}
+	return errs
+}
+
+// funcValueField returns the struct field val is loaded from: s.f through a pointer
+// (FieldAddr) or a value (Field), or an element of a map or slice stored in one.
+func funcValueField(val ssa.Value) *types.Var {
+	switch v := val.(type) {
+	case *ssa.UnOp:
+		if v.Op == token.MUL {
+			return funcValueField(v.X)
+		}
+	case *ssa.Lookup:
+		return funcValueField(v.X)
+	case *ssa.IndexAddr:
+		return funcValueField(v.X)
+	case *ssa.FieldAddr:
+		if ptr, ok := v.X.Type().Underlying().(*types.Pointer); ok {
+			if st, ok := ptr.Elem().Underlying().(*types.Struct); ok {
+				return st.Field(v.Field)
+			}
+		}
+	case *ssa.Field:
+		if st, ok := v.X.Type().Underlying().(*types.Struct); ok {
+			return st.Field(v.Field)
+		}
+	}
+	return nil
+}
+
 // lookupParameterFlowFact returns the ParameterFlowFact for a types.Func,
 // checking local facts first, then imported facts.
 func (a *Analyzer) lookupParameterFlowFact(fn *types.Func) *facts.ParameterFlowFact {
@@ -579,7 +797,91 @@ func (a *Analyzer) getErrorsFromMakeInterface(v *ssa.MakeInterface) []facts.Erro
 	if namedType == nil {
 		return nil
 	}
-	return a.resolveErrorInfoFromTypeName(namedType.Obj())
+	form := facts.FormValue
+	if _, ok := v.X.Type().Underlying().(*types.Pointer); ok {
+		form = facts.FormPointer
+	}
+	return withForm(a.resolveErrorInfoFromTypeName(namedType.Obj()), form)
+}
+
+// withForm records the form custom error types are returned in.
+func withForm(errs []facts.ErrorInfo, form facts.ErrorForm) []facts.ErrorInfo {
+	for i := range errs {
+		errs[i].Forms = form
+	}
+	return errs
+}
+
+// getErrorsFromUnwrapFields traces the values stored into the Unwrap fields of a
+// wrapper error literal (e.g., &OpError{Op: "read", Err: ErrTimeout}).
+// The returned errors are marked as reachable through the wrapper type.
+func (a *Analyzer) getErrorsFromUnwrapFields(val ssa.Value, visited map[ssa.Value]bool, depth int) []facts.ErrorInfo {
+	wrapperKey, fieldValues := a.unwrapFieldValues(val)
+
+	var errs []facts.ErrorInfo
+	for _, fieldValue := range fieldValues {
+		fieldErrs := a.traceValueToErrors(fieldValue, visited, depth+1)
+		for i := range fieldErrs {
+			fieldErrs[i].Wrapped = true
+			if fieldErrs[i].WrappedBy == "" {
+				fieldErrs[i].WrappedBy = wrapperKey
+			}
+		}
+		errs = append(errs, fieldErrs...)
+	}
+	return errs
+}
+
+// unwrapFieldValues returns the key of the wrapper type allocated by val and the
+// values stored into the fields its Unwrap method returns.
+// It handles both &T{...} (Alloc) and T{...} (load of an Alloc) literals.
+func (a *Analyzer) unwrapFieldValues(val ssa.Value) (string, []ssa.Value) {
+	if load, ok := val.(*ssa.UnOp); ok && load.Op == token.MUL {
+		val = load.X
+	}
+	alloc, ok := val.(*ssa.Alloc)
+	if !ok {
+		return "", nil
+	}
+	ptrType, ok := alloc.Type().(*types.Pointer)
+	if !ok {
+		return "", nil
+	}
+	namedType := internal.ExtractNamedType(ptrType.Elem())
+	if namedType == nil || namedType.Obj().Pkg() == nil {
+		return "", nil
+	}
+	structType, ok := namedType.Underlying().(*types.Struct)
+	if !ok {
+		return "", nil
+	}
+
+	var unwrapFact facts.UnwrapFact
+	if !a.pass.ImportObjectFact(namedType.Obj(), &unwrapFact) {
+		return "", nil
+	}
+
+	var values []ssa.Value
+	for _, ref := range *alloc.Referrers() {
+		fieldAddr, ok := ref.(*ssa.FieldAddr)
+		if !ok || !unwrapFact.HasField(structType.Field(fieldAddr.Field).Name()) {
+			continue
+		}
+		for _, fieldRef := range *fieldAddr.Referrers() {
+			store, ok := fieldRef.(*ssa.Store)
+			if !ok || store.Addr != fieldAddr {
+				continue
+			}
+			// Unwrap() []error fields are usually initialized with a slice literal
+			if slice, ok := store.Val.(*ssa.Slice); ok {
+				values = append(values, extractSliceElements(slice)...)
+				continue
+			}
+			values = append(values, store.Val)
+		}
+	}
+
+	return namedType.Obj().Pkg().Path() + "." + namedType.Obj().Name(), values
 }
 
 // getErrorsFromAlloc checks if an Alloc creates a known custom error type.
@@ -592,7 +894,7 @@ func (a *Analyzer) getErrorsFromAlloc(v *ssa.Alloc) []facts.ErrorInfo {
 	if namedType == nil {
 		return nil
 	}
-	return a.resolveErrorInfoFromTypeName(namedType.Obj())
+	return withForm(a.resolveErrorInfoFromTypeName(namedType.Obj()), facts.FormPointer)
 }
 
 // getErrorsFromGlobal checks if a Global is a known error variable.
@@ -624,10 +926,9 @@ func (a *Analyzer) getErrorsFromGlobal(v *ssa.Global) []facts.ErrorInfo {
 				Name:    varObj.Name(),
 			}}
 		}
-		return nil
 	}
 
-	// For imported variables, only use if they have an explicit fact
+	// Imported variables and local aliases of sentinels have an explicit fact
 	var errorFact facts.ErrorFact
 	if a.pass.ImportObjectFact(varObj, &errorFact) {
 		return []facts.ErrorInfo{{
@@ -639,16 +940,20 @@ func (a *Analyzer) getErrorsFromGlobal(v *ssa.Global) []facts.ErrorInfo {
 	return nil
 }
 
-// deduplicateErrors removes duplicate errors from the list.
+// deduplicateErrors removes duplicate errors from the list. The forms of duplicates
+// are merged into the first occurrence, which is hidden from pkg/errors.Cause if any is.
 func (a *Analyzer) deduplicateErrors(errs []facts.ErrorInfo) []facts.ErrorInfo {
-	seen := make(map[string]bool)
+	seen := make(map[string]int)
 	var result []facts.ErrorInfo
 	for _, s := range errs {
 		key := s.Key()
-		if !seen[key] {
-			seen[key] = true
-			result = append(result, s)
+		if i, ok := seen[key]; ok {
+			result[i].CauseHidden = result[i].CauseHidden || s.CauseHidden
+			result[i].Forms |= s.Forms
+			continue
 		}
+		seen[key] = len(result)
+		result = append(result, s)
 	}
 	return result
 }
@@ -679,6 +984,12 @@ func (a *Analyzer) resolveParameterFlowErrors(call *ssa.Call, visited map[ssa.Va
 			if flow.Wrapped {
 				argErrs[i].Wrapped = true
 			}
+			if flow.CauseHidden {
+				argErrs[i].CauseHidden = true
+			}
+			if argErrs[i].WrappedBy == "" {
+				argErrs[i].WrappedBy = flow.WrappedBy
+			}
 		}
 		errs = append(errs, argErrs...)
 	}
@@ -728,8 +1039,10 @@ func (a *Analyzer) DetectParameterFlow(fn *types.Func, errorPositions []int) *fa
 							continue
 						}
 						adjustedFlow := facts.ParameterFlowInfo{
-							ParamIndex: adjustedIndex,
-							Wrapped:    flow.Wrapped,
+							ParamIndex:  adjustedIndex,
+							Wrapped:     flow.Wrapped,
+							WrappedBy:   flow.WrappedBy,
+							CauseHidden: flow.CauseHidden,
 						}
 						fact.AddFlow(adjustedFlow)
 					}
@@ -781,8 +1094,11 @@ func (a *Analyzer) DetectFunctionParamCallFlow(fn *types.Func, errorPositions []
 							continue
 						}
 						adjustedFlow := facts.FunctionParamCallFlowInfo{
-							ParamIndex: adjustedIndex,
-							Wrapped:    flow.Wrapped,
+							ParamIndex:  adjustedIndex,
+							Wrapped:     flow.Wrapped,
+							CauseHidden: flow.CauseHidden,
+							Elements:    flow.Elements,
+							Method:      flow.Method,
 						}
 						fact.AddCallFlow(adjustedFlow)
 					}
@@ -812,21 +1128,43 @@ func (a *Analyzer) traceValueToFunctionParamCalls(val ssa.Value, params []*ssa.P
 		// Check if the callee is a parameter (dynamic call to function parameter)
 		if param, ok := v.Call.Value.(*ssa.Parameter); ok {
 			// Find the parameter index
+			for i, p := range params {
+				if p != param {
+					continue
+				}
+				if _, ok := param.Type().(*types.TypeParam); ok && v.Call.IsInvoke() {
+					// Method call on a parameter of type parameter type (r.Get(id) with r R):
+					// the type argument decides the method at each call site
+					flows = append(flows, facts.FunctionParamCallFlowInfo{
+						ParamIndex: i,
+						Method:     v.Call.Method.Name(),
+					})
+					continue
+				}
+				flows = append(flows, facts.FunctionParamCallFlowInfo{
+					ParamIndex: i,
+					Wrapped:    false,
+				})
+			}
+		}
+
+		// Or an element of a variadic or slice parameter (for _, fn := range fns { fn() })
+		if param := elementParameter(v.Call.Value); param != nil {
 			for i, p := range params {
 				if p == param {
 					flows = append(flows, facts.FunctionParamCallFlowInfo{
 						ParamIndex: i,
-						Wrapped:    false,
+						Elements:   true,
 					})
 				}
 			}
 		}
 
-		// Also check for fmt.Errorf wrapping the result of a function parameter call,
-		// or transitive call flow through another higher-order function
+		// Also check for wrapper functions (fmt.Errorf, errors.Wrap, ...) wrapping the result
+		// of a function parameter call, or transitive call flow through another higher-order function
 		callee := v.Call.StaticCallee()
 		if callee != nil {
-			if isFmtErrorfSSA(callee) {
+			if isWrapperSSA(callee) {
 				wrappedFlows := a.analyzeErrorfWrappingForFunctionParamCalls(v, params, visited, depth)
 				flows = append(flows, wrappedFlows...)
 			} else {
@@ -854,22 +1192,62 @@ func (a *Analyzer) traceValueToFunctionParamCalls(val ssa.Value, params []*ssa.P
 	return deduplicateFunctionParamCallFlows(flows)
 }
 
-// analyzeErrorfWrappingForFunctionParamCalls checks if fmt.Errorf wraps the result of a function parameter call
-func (a *Analyzer) analyzeErrorfWrappingForFunctionParamCalls(call *ssa.Call, params []*ssa.Parameter, visited map[ssa.Value]bool, depth int) []facts.FunctionParamCallFlowInfo {
-	variadicArgs, wrapIndices := getWrappedArgIndices(call)
-	if len(wrapIndices) == 0 {
+// elementParameter returns the slice parameter whose element val is loaded from
+// (fns[i] or the value of a range loop over fns), or nil.
+func elementParameter(val ssa.Value) *ssa.Parameter {
+	param, _ := elementSlice(val).(*ssa.Parameter)
+	return param
+}
+
+// elementSlice returns the slice whose element val is loaded from (fns[i] or the value
+// of a range loop over fns), or nil.
+func elementSlice(val ssa.Value) ssa.Value {
+	load, ok := val.(*ssa.UnOp)
+	if !ok || load.Op != token.MUL {
 		return nil
 	}
+	indexAddr, ok := load.X.(*ssa.IndexAddr)
+	if !ok {
+		return nil
+	}
+	return indexAddr.X
+}
 
-	var flows []facts.FunctionParamCallFlowInfo
-	for _, wrapIdx := range wrapIndices {
-		if wrapIdx >= len(variadicArgs) {
+// sliceElements returns the values stored into a slice built from a literal or from
+// variadic arguments: RunAll(f, g) and RunAll([]func() error{f, g}...) both slice an
+// array allocation whose elements are stored one by one. It returns nil for other slices.
+func sliceElements(val ssa.Value) []ssa.Value {
+	slice, ok := val.(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+	var elems []ssa.Value
+	for _, ref := range *alloc.Referrers() {
+		indexAddr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
 			continue
 		}
+		for _, use := range *indexAddr.Referrers() {
+			if store, ok := use.(*ssa.Store); ok && store.Addr == indexAddr {
+				elems = append(elems, store.Val)
+			}
+		}
+	}
+	return elems
+}
 
-		paramCallFlows := a.traceValueToFunctionParamCalls(variadicArgs[wrapIdx], params, visited, depth+1)
+// analyzeErrorfWrappingForFunctionParamCalls checks if a wrapper call wraps the result of a function parameter call
+func (a *Analyzer) analyzeErrorfWrappingForFunctionParamCalls(call *ssa.Call, params []*ssa.Parameter, visited map[ssa.Value]bool, depth int) []facts.FunctionParamCallFlowInfo {
+	var flows []facts.FunctionParamCallFlowInfo
+	args, w := getWrappedArgs(call)
+	for _, arg := range args {
+		paramCallFlows := a.traceValueToFunctionParamCalls(arg, params, visited, depth+1)
 		for i := range paramCallFlows {
-			paramCallFlows[i].Wrapped = true
+			paramCallFlows[i] = w.CarryCallFlow(paramCallFlows[i])
 		}
 		flows = append(flows, paramCallFlows...)
 	}
@@ -878,14 +1256,22 @@ func (a *Analyzer) analyzeErrorfWrappingForFunctionParamCalls(call *ssa.Call, pa
 }
 
 // deduplicateFunctionParamCallFlows removes duplicate function parameter call flows.
+// The first occurrence is hidden from pkg/errors.Cause if any duplicate is.
 func deduplicateFunctionParamCallFlows(flows []facts.FunctionParamCallFlowInfo) []facts.FunctionParamCallFlowInfo {
-	seen := make(map[int]bool)
+	type flowKey struct {
+		paramIndex int
+		method     string
+	}
+	seen := make(map[flowKey]int)
 	var result []facts.FunctionParamCallFlowInfo
 	for _, flow := range flows {
-		if !seen[flow.ParamIndex] {
-			seen[flow.ParamIndex] = true
-			result = append(result, flow)
+		key := flowKey{flow.ParamIndex, flow.Method}
+		if i, ok := seen[key]; ok {
+			result[i].CauseHidden = result[i].CauseHidden || flow.CauseHidden
+			continue
 		}
+		seen[key] = len(result)
+		result = append(result, flow)
 	}
 	return result
 }
@@ -927,14 +1313,36 @@ func (a *Analyzer) traceTransitiveFunctionParamCallFlow(call *ssa.Call, params [
 		// Trace the argument back to see if it's a parameter of the outer function
 		arg := args[argIdx]
 		if param, ok := arg.(*ssa.Parameter); ok {
+			// A method flow continues only through parameters of type parameter type
+			if _, ok := param.Type().(*types.TypeParam); callFlow.Method != "" && !ok {
+				continue
+			}
 			for i, p := range params {
 				if p == param {
 					flows = append(flows, facts.FunctionParamCallFlowInfo{
-						ParamIndex: i,
-						Wrapped:    callFlow.Wrapped,
+						ParamIndex:  i,
+						Wrapped:     callFlow.Wrapped,
+						CauseHidden: callFlow.CauseHidden,
+						Elements:    callFlow.Elements,
+						Method:      callFlow.Method,
 					})
 				}
 			}
+		} else if callFlow.Method != "" {
+			continue
+		} else if callFlow.Elements {
+			// Parameters passed as elements: RunAll(fn, other)
+			for _, elem := range sliceElements(arg) {
+				for i, p := range params {
+					if p == elem {
+						flows = append(flows, facts.FunctionParamCallFlowInfo{
+							ParamIndex:  i,
+							Wrapped:     callFlow.Wrapped,
+							CauseHidden: callFlow.CauseHidden,
+						})
+					}
+				}
+			}
 		} else {
 			// Handle cases where the argument is derived from a parameter
 			// through phi nodes, etc. by recursively tracing
@@ -943,6 +1351,9 @@ func (a *Analyzer) traceTransitiveFunctionParamCallFlow(call *ssa.Call, params [
 				if callFlow.Wrapped {
 					paramFlows[i].Wrapped = true
 				}
+				if callFlow.CauseHidden {
+					paramFlows[i].CauseHidden = true
+				}
 			}
 			flows = append(flows, paramFlows...)
 		}
@@ -980,10 +1391,10 @@ func (a *Analyzer) traceValueToParameters(val ssa.Value, params []*ssa.Parameter
 		}
 
 	case *ssa.Call:
-		// Check if this is fmt.Errorf with %w wrapping a parameter
+		// Check if this is a wrapper function (fmt.Errorf with %w, errors.Wrap, ...) wrapping a parameter
 		callee := v.Call.StaticCallee()
 		if callee != nil {
-			if isFmtErrorfSSA(callee) {
+			if isWrapperSSA(callee) {
 				wrappedFlows := a.analyzeErrorfWrapping(v, params, visited, depth)
 				flows = append(flows, wrappedFlows...)
 			} else {
@@ -1000,6 +1411,19 @@ func (a *Analyzer) traceValueToParameters(val ssa.Value, params []*ssa.Parameter
 	case *ssa.MakeInterface:
 		flows = append(flows, a.traceValueToParameters(v.X, params, visited, depth+1)...)
 
+		// Parameters stored in the Unwrap fields of a wrapper type (e.g., &OpError{Err: err})
+		wrapperKey, fieldValues := a.unwrapFieldValues(v.X)
+		for _, fieldValue := range fieldValues {
+			fieldFlows := a.traceValueToParameters(fieldValue, params, visited, depth+1)
+			for i := range fieldFlows {
+				fieldFlows[i].Wrapped = true
+				if fieldFlows[i].WrappedBy == "" {
+					fieldFlows[i].WrappedBy = wrapperKey
+				}
+			}
+			flows = append(flows, fieldFlows...)
+		}
+
 	case *ssa.Extract:
 		flows = append(flows, a.traceValueToParameters(v.Tuple, params, visited, depth+1)...)
 	}
@@ -1034,6 +1458,12 @@ func (a *Analyzer) traceTransitiveParameterFlow(call *ssa.Call, params []*ssa.Pa
 			if flow.Wrapped {
 				argFlows[i].Wrapped = true
 			}
+			if flow.CauseHidden {
+				argFlows[i].CauseHidden = true
+			}
+			if argFlows[i].WrappedBy == "" {
+				argFlows[i].WrappedBy = flow.WrappedBy
+			}
 		}
 		flows = append(flows, argFlows...)
 	}
@@ -1041,59 +1471,84 @@ func (a *Analyzer) traceTransitiveParameterFlow(call *ssa.Call, params []*ssa.Pa
 	return flows
 }
 
-// isFmtErrorfSSA checks if the callee is fmt.Errorf
-func isFmtErrorfSSA(callee *ssa.Function) bool {
-	if callee.Pkg == nil {
+// isWrapperSSA checks if the callee is a known wrapper function (fmt.Errorf, errors.Wrap, ...)
+func isWrapperSSA(callee *ssa.Function) bool {
+	fn, ok := callee.Object().(*types.Func)
+	if !ok {
 		return false
 	}
-	return callee.Pkg.Pkg.Path() == "fmt" && callee.Name() == "Errorf"
+	_, ok = internal.LookupWrapper(fn)
+	return ok
 }
 
-// getWrappedArgIndices extracts the wrapped argument indices from a fmt.Errorf call.
-// This is the common logic for analyzing fmt.Errorf wrapping patterns.
-func getWrappedArgIndices(call *ssa.Call) (variadicArgs []ssa.Value, wrapIndices []int) {
+// getWrappedArgs extracts the arguments carried to the result of a wrapper call:
+// the %w operands of format-based wrappers and the error argument of errors.Wrap-style wrappers.
+// It also returns the description of the wrapper.
+func getWrappedArgs(call *ssa.Call) ([]ssa.Value, internal.WrapperFunc) {
+	callee := call.Call.StaticCallee()
+	if callee == nil {
+		return nil, internal.WrapperFunc{}
+	}
+	fn, ok := callee.Object().(*types.Func)
+	if !ok {
+		return nil, internal.WrapperFunc{}
+	}
+	w, ok := internal.LookupWrapper(fn)
+	if !ok {
+		return nil, w
+	}
+
 	args := call.Call.Args
-	if len(args) < 1 {
-		return nil, nil
+	// Static method calls pass the receiver as the first argument
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		if len(args) == 0 {
+			return nil, w
+		}
+		args = args[1:]
+	}
+
+	var wrapped []ssa.Value
+	if w.ArgIndex >= 0 && w.ArgIndex < len(args) {
+		wrapped = append(wrapped, args[w.ArgIndex])
+	}
+	if w.FormatIndex < 0 || w.FormatIndex >= len(args) {
+		return wrapped, w
 	}
 
-	formatStr := extractConstantString(args[0])
+	formatStr := extractConstantString(args[w.FormatIndex])
 	if formatStr == "" {
-		return nil, nil
+		return wrapped, w
 	}
 
-	wrapIndices = internal.FindWrapVerbIndices(formatStr)
+	wrapIndices := internal.FindWrapVerbIndices(formatStr)
 	if len(wrapIndices) == 0 {
-		return nil, nil
+		return wrapped, w
 	}
 
 	// Handle variadic arguments - they may be passed as a slice
-	variadicArgs = args[1:]
-	if len(args) == 2 {
-		if slice, ok := args[1].(*ssa.Slice); ok {
+	variadicArgs := args[w.FormatIndex+1:]
+	if len(variadicArgs) == 1 {
+		if slice, ok := variadicArgs[0].(*ssa.Slice); ok {
 			variadicArgs = extractSliceElements(slice)
 		}
 	}
 
-	return variadicArgs, wrapIndices
+	for _, wrapIdx := range wrapIndices {
+		if wrapIdx < len(variadicArgs) && variadicArgs[wrapIdx] != nil {
+			wrapped = append(wrapped, variadicArgs[wrapIdx])
+		}
+	}
+	return wrapped, w
 }
 
-// analyzeErrorfWrapping analyzes fmt.Errorf calls for %w verbs that wrap parameters
+// analyzeErrorfWrapping analyzes wrapper calls (fmt.Errorf with %w, errors.Wrap, ...) that wrap parameters
 func (a *Analyzer) analyzeErrorfWrapping(call *ssa.Call, params []*ssa.Parameter, visited map[ssa.Value]bool, depth int) []facts.ParameterFlowInfo {
-	variadicArgs, wrapIndices := getWrappedArgIndices(call)
-	if len(wrapIndices) == 0 {
-		return nil
-	}
-
 	var flows []facts.ParameterFlowInfo
-	for _, wrapIdx := range wrapIndices {
-		if wrapIdx >= len(variadicArgs) {
-			continue
-		}
-
-		paramFlows := a.traceValueToParameters(variadicArgs[wrapIdx], params, visited, depth+1)
+	args, w := getWrappedArgs(call)
+	for _, arg := range args {
+		paramFlows := a.traceValueToParameters(arg, params, visited, depth+1)
 		for i := range paramFlows {
-			paramFlows[i].Wrapped = true
+			paramFlows[i] = w.CarryFlow(paramFlows[i])
 		}
 		flows = append(flows, paramFlows...)
 	}
@@ -1175,15 +1630,18 @@ func findErrorParamIndices(sig *types.Signature) []int {
 	return indices
 }
 
-// deduplicateFlows removes duplicate parameter flows.
+// deduplicateFlows removes duplicate parameter flows. The first occurrence is hidden
+// from pkg/errors.Cause if any duplicate is.
 func deduplicateFlows(flows []facts.ParameterFlowInfo) []facts.ParameterFlowInfo {
-	seen := make(map[int]bool)
+	seen := make(map[int]int)
 	var result []facts.ParameterFlowInfo
 	for _, flow := range flows {
-		if !seen[flow.ParamIndex] {
-			seen[flow.ParamIndex] = true
-			result = append(result, flow)
+		if i, ok := seen[flow.ParamIndex]; ok {
+			result[i].CauseHidden = result[i].CauseHidden || flow.CauseHidden
+			continue
 		}
+		seen[flow.ParamIndex] = len(result)
+		result = append(result, flow)
 	}
 	return result
 }
@@ -0,0 +1,169 @@
+package ssaanalysis
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// TypedNilReturn is a return statement converting a possibly nil custom error pointer
+// to an interface result.
+type TypedNilReturn struct {
+	Pos  token.Pos  // position of the return statement
+	From types.Type // the pointer type, e.g. *MyError
+	To   types.Type // the result type, e.g. error
+}
+
+// TypedNilReturns finds the return statements of the package that convert a custom
+// error pointer (*MyError) that may be nil to an interface result. A nil *MyError
+// stored in an error is not equal to nil. Pointers to composite literals and sentinel
+// variables are never nil and are not returned.
+func TypedNilReturns(ssaResult *buildssa.SSA) []TypedNilReturn {
+	var returns []TypedNilReturn
+	for _, fn := range ssaResult.SrcFuncs {
+		results := fn.Signature.Results()
+		for _, block := range fn.Blocks {
+			ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+			if !ok || !ret.Pos().IsValid() {
+				continue
+			}
+			for i, result := range ret.Results {
+				mi, ok := result.(*ssa.MakeInterface)
+				if !ok || i >= results.Len() || !isErrorPointer(mi.X.Type()) || !internal.IsErrorResultType(results.At(i).Type()) {
+					continue
+				}
+				if mayBeNil(mi.X, block, make(map[ssa.Value]bool)) {
+					returns = append(returns, TypedNilReturn{Pos: ret.Pos(), From: mi.X.Type(), To: results.At(i).Type()})
+				}
+			}
+		}
+	}
+	return returns
+}
+
+// isErrorPointer reports whether t is a pointer to a custom error type.
+func isErrorPointer(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Pointer)
+	return ok && internal.IsErrorResultType(t)
+}
+
+// mayBeNil reports whether the pointer val returned in block may be nil: the nil
+// constant, a parameter, or the result of a call of a function of the same package that
+// returns nil, not checked against nil first. Callees without a body in the package
+// (other packages, function values) are assumed not to return nil.
+func mayBeNil(val ssa.Value, block *ssa.BasicBlock, visited map[ssa.Value]bool) bool {
+	if visited[val] {
+		return false
+	}
+	visited[val] = true
+
+	switch v := val.(type) {
+	case *ssa.Const:
+		return v.IsNil()
+	case *ssa.Parameter:
+		return !checkedNotNil(val, block)
+	case *ssa.Call:
+		return calleeReturnsNil(v, 0) && !checkedNotNil(val, block)
+	case *ssa.Extract:
+		call, ok := v.Tuple.(*ssa.Call)
+		return ok && calleeReturnsNil(call, v.Index) && !checkedNotNil(val, block)
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if mayBeNil(edge, block, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// calleeReturnsNil reports whether the static callee of call, a function of the same
+// package, returns nil as its result index: a return operand is the nil constant, or a
+// Phi node with a nil edge (var e *MyError; if cond { e = &MyError{} }; return e).
+func calleeReturnsNil(call *ssa.Call, index int) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Pkg != call.Parent().Pkg {
+		return false
+	}
+	return returnsNil(callee, index)
+}
+
+// NilableErrorPointerResults returns the indices of the custom error pointer results
+// (*MyError) fn returns nil in on some path. A function that never does, such as
+// func NewMyError() *MyError, constructs the error rather than failing with it.
+func (a *Analyzer) NilableErrorPointerResults(fn *types.Func) []int {
+	ssaFn := a.FindSSAFunction(fn)
+	if ssaFn == nil {
+		return nil
+	}
+	var indices []int
+	results := ssaFn.Signature.Results()
+	for i := 0; i < results.Len(); i++ {
+		if isErrorPointer(results.At(i).Type()) && returnsNil(ssaFn, i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// returnsNil reports whether fn returns nil as its result index.
+func returnsNil(fn *ssa.Function, index int) bool {
+	for _, block := range fn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok || index >= len(ret.Results) {
+			continue
+		}
+		if isNilResult(ret.Results[index], make(map[ssa.Value]bool)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNilResult(val ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[val] {
+		return false
+	}
+	visited[val] = true
+	switch v := val.(type) {
+	case *ssa.Const:
+		return v.IsNil()
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if isNilResult(edge, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkedNotNil reports whether block is only reached when val != nil.
+func checkedNotNil(val ssa.Value, block *ssa.BasicBlock) bool {
+	if val.Referrers() == nil {
+		return false
+	}
+	for _, ref := range *val.Referrers() {
+		cmp, ok := ref.(*ssa.BinOp)
+		if !ok || (cmp.Op != token.NEQ && cmp.Op != token.EQL) || !isNilConst(cmp.Y) || cmp.Referrers() == nil {
+			continue
+		}
+		for _, cmpRef := range *cmp.Referrers() {
+			ifInstr, ok := cmpRef.(*ssa.If)
+			if !ok {
+				continue
+			}
+			succ := ifInstr.Block().Succs[0]
+			if cmp.Op == token.EQL {
+				succ = ifInstr.Block().Succs[1]
+			}
+			if len(succ.Preds) == 1 && succ.Dominates(block) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package ssaanalysis
+
+import (
+	"go/types"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/ssa"
+)
+
+// getErrorsFromMethodFlowArg returns the errors of the method name of the type of arg,
+// the argument of a generic function calling name on its parameter (Fetch(repo, id) with
+// func Fetch[R Repository](r R, id int) error { return r.Get(id) }). A concrete type
+// argument narrows the errors to its own method; an interface or type parameter yields
+// the errors of all implementations. A parameter of type parameter type passes the flow
+// on to the call sites of the enclosing function instead.
+func (a *Analyzer) getErrorsFromMethodFlowArg(arg ssa.Value, name string) []facts.ErrorInfo {
+	t := arg.Type()
+	if _, ok := t.(*types.TypeParam); ok {
+		if _, ok := arg.(*ssa.Parameter); ok {
+			return nil
+		}
+	}
+
+	if iface := internal.GetInterfaceType(t); iface != nil {
+		method := internal.InterfaceMethodByName(iface, name)
+		if method == nil {
+			return nil
+		}
+		var ifaceFact facts.InterfaceMethodFact
+		if a.pass.ImportObjectFact(method, &ifaceFact) {
+			return ifaceFact.Errors
+		}
+		var errs []facts.ErrorInfo
+		for _, concreteType := range a.InterfaceImpls.GetImplementingTypes(iface) {
+			if impl := internal.FindMethodImplementation(concreteType, method); impl != nil {
+				errs = append(errs, a.lookupFunctionErrorsFact(impl)...)
+			}
+		}
+		return errs
+	}
+
+	method := internal.LookupMethod(t, name)
+	if method == nil {
+		return nil
+	}
+	return a.lookupFunctionErrorsFact(method)
+}
@@ -0,0 +1,152 @@
+package asform
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError has a value receiver, so both ValidationError and *ValidationError are errors
+type ValidationError struct { // want ValidationError:`asform.ValidationError`
+	Field string
+}
+
+func (e ValidationError) Error() string { return "invalid " + e.Field }
+
+type CodeError struct { // want CodeError:`asform.CodeError`
+	Code int
+}
+
+func (e CodeError) Error() string { return "code" }
+
+func Validate() error { // want Validate:`\[asform.ValidationError\]`
+	return &ValidationError{Field: "name"}
+}
+
+func ValidateWrapped() error { // want ValidateWrapped:`\[asform.ValidationError\]`
+	return fmt.Errorf("validate: %w", &ValidationError{Field: "name"})
+}
+
+func Code() error { // want Code:`\[asform.CodeError\]`
+	return CodeError{Code: 1}
+}
+
+func Either(ptr bool) error { // want Either:`\[asform.CodeError\]`
+	if ptr {
+		return &CodeError{Code: 1}
+	}
+	return CodeError{Code: 2}
+}
+
+// EitherDeferred replaces its error with CodeError in both forms
+func EitherDeferred(ptr bool) (err error) { // want EitherDeferred:`\[asform.CodeError\]`
+	defer func() {
+		if err != nil {
+			err = CodeError{Code: 2}
+			if ptr {
+				err = &CodeError{Code: 1}
+			}
+		}
+	}()
+	return errors.New("failed")
+}
+
+// =============================================================================
+// Test 1: errors.As target of the value form of an error returned as a pointer
+// =============================================================================
+
+func TestAsValueTarget() {
+	err := Validate()
+	var ve ValidationError
+	if errors.As(err, &ve) { // want "errors.As target of type ValidationError never matches asform.ValidationError, which is only returned as a pointer"
+		println(ve.Field)
+	}
+}
+
+func TestAsPointerTarget() {
+	err := Validate()
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		println(ve.Field)
+	}
+}
+
+func TestAsValueTargetWrapped() {
+	err := ValidateWrapped()
+	var ve ValidationError
+	if errors.As(err, &ve) { // want "errors.As target of type ValidationError never matches asform.ValidationError, which is only returned as a pointer"
+		println(ve.Field)
+	}
+}
+
+// =============================================================================
+// Test 2: errors.As target of the pointer form of an error returned as a value
+// =============================================================================
+
+func TestAsPointerTargetOfValue() {
+	err := Code()
+	var ce *CodeError
+	if errors.As(err, &ce) { // want "errors.As target of type \\*CodeError never matches asform.CodeError, which is only returned as a value"
+		println(ce.Code)
+	}
+}
+
+func TestAsValueTargetOfValue() {
+	err := Code()
+	var ce CodeError
+	if errors.As(err, &ce) {
+		println(ce.Code)
+	}
+}
+
+// =============================================================================
+// Test 3: Type switch cases
+// =============================================================================
+
+func TestTypeSwitchMismatch() {
+	err := Code()
+	switch err.(type) {
+	case *CodeError: // want "case \\*CodeError never matches asform.CodeError, which is only returned as a value"
+		println("code")
+	}
+}
+
+func TestTypeSwitchMatch() {
+	err := Code()
+	switch err.(type) {
+	case CodeError:
+		println("code")
+	}
+}
+
+// =============================================================================
+// Test 4: Errors returned in both forms match either target
+// =============================================================================
+
+func TestEitherForm() {
+	err := Either(true)
+	var ce *CodeError
+	if errors.As(err, &ce) {
+		println(ce.Code)
+	}
+}
+
+func TestEitherFormDeferred() {
+	err := EitherDeferred(true)
+	var ce CodeError
+	if errors.As(err, &ce) {
+		println(ce.Code)
+	}
+	var cp *CodeError
+	if errors.As(err, &cp) {
+		println(cp.Code)
+	}
+}
+
+// An interface target matches either form, but does not check for a specific error
+func TestInterfaceTarget() {
+	err := Code() // want "missing errors.Is check for asform.CodeError"
+	var coder interface{ Error() string }
+	if errors.As(err, &coder) {
+		println(coder.Error())
+	}
+}
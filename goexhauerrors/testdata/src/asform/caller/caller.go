@@ -0,0 +1,23 @@
+package caller
+
+import (
+	"errors"
+
+	"asform"
+)
+
+func TestCrossPackageMismatch() {
+	err := asform.Validate()
+	var ve asform.ValidationError
+	if errors.As(err, &ve) { // want "errors.As target of type asform.ValidationError never matches asform.ValidationError, which is only returned as a pointer"
+		println(ve.Field)
+	}
+}
+
+func TestCrossPackageMatch() {
+	err := asform.Validate()
+	var ve *asform.ValidationError
+	if errors.As(err, &ve) {
+		println(ve.Field)
+	}
+}
@@ -0,0 +1,137 @@
+package apperr
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`checkhelper/apperr.ErrNotFound`
+var ErrGone = errors.New("gone")          // want ErrGone:`checkhelper/apperr.ErrGone`
+var ErrConflict = errors.New("conflict")  // want ErrConflict:`checkhelper/apperr.ErrConflict`
+var errInternal = errors.New("internal")
+
+type ValidationError struct { // want ValidationError:`checkhelper/apperr.ValidationError`
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return "validation error: " + e.Field
+}
+
+// =============================================================================
+// Annotated helpers
+// =============================================================================
+
+// IsNotFound reports whether err is a not-found error.
+//
+//goexhauerrors:checks ErrNotFound ErrGone
+func IsNotFound(err error) bool { // want IsNotFound:`checks:param0:\[checkhelper/apperr.ErrNotFound,checkhelper/apperr.ErrGone\]` IsNotFound:`\[param0:\[checkhelper/apperr.ErrNotFound,checkhelper/apperr.ErrGone\]\]`
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrGone)
+}
+
+// IsValidation reports whether err is a validation error.
+// The body is opaque to the linter, so the directive is the only source of truth.
+//
+//goexhauerrors:checks ValidationError
+func IsValidation(err error) bool { // want IsValidation:`checks:param0:\[checkhelper/apperr.ValidationError\]`
+	return kindOf(err) == "validation"
+}
+
+// HasCode reports whether err carries the given code. The error is the second parameter.
+//
+//goexhauerrors:checks ErrConflict
+func HasCode(code int, err error) bool { // want HasCode:`checks:param1:\[checkhelper/apperr.ErrConflict\]`
+	return code == 409 && err != nil
+}
+
+// Code classifies an error.
+type Code int
+
+const (
+	CodeUnknown Code = iota
+	CodeNotFound
+	CodeConflict
+)
+
+// CodeOf returns the code of err. Comparing it with a constant checks the listed errors.
+//
+//goexhauerrors:checks CodeNotFound=ErrNotFound|ErrGone CodeConflict=ErrConflict
+func CodeOf(err error) Code { // want CodeOf:`checks:param0:\[\] checkhelper/apperr.CodeNotFound=\[checkhelper/apperr.ErrNotFound,checkhelper/apperr.ErrGone\] checkhelper/apperr.CodeConflict=\[checkhelper/apperr.ErrConflict\]`
+	if err == nil {
+		return CodeUnknown
+	}
+	return CodeNotFound
+}
+
+func kindOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	return "validation"
+}
+
+// =============================================================================
+// Invalid directives
+// =============================================================================
+
+//goexhauerrors:checks ErrMissing // want "unknown error ErrMissing in goexhauerrors:checks directive"
+func IsMissing(err error) bool {
+	return err != nil
+}
+
+//goexhauerrors:checks CodeMissing=ErrNotFound // want "unknown constant CodeMissing in goexhauerrors:checks directive"
+func MissingCode(err error) Code {
+	return CodeUnknown
+}
+
+//goexhauerrors:checks ErrNotFound // want "goexhauerrors:checks requires NoErrorParam to have an error parameter"
+func NoErrorParam(code int) bool {
+	return code == 404
+}
+
+// =============================================================================
+// Same-package usage
+// =============================================================================
+
+func Find(id string) error { // want Find:`\[checkhelper/apperr.ErrNotFound, checkhelper/apperr.ErrGone\]`
+	if id == "" {
+		return ErrNotFound
+	}
+	return ErrGone
+}
+
+func GoodLocalCaller() {
+	err := Find("x")
+	if IsNotFound(err) {
+		println("not found")
+	}
+}
+
+func BadLocalCaller() {
+	err := Find("x") // want "missing errors.Is check for checkhelper/apperr.ErrNotFound" "missing errors.Is check for checkhelper/apperr.ErrGone"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// unexportedHelper covers an unexported error, which is resolved in the local scope.
+//
+//goexhauerrors:checks errInternal
+func unexportedHelper(err error) bool { // want unexportedHelper:`checks:param0:\[checkhelper/apperr.errInternal\]`
+	return err != nil
+}
+
+// =============================================================================
+// Code comparisons
+// =============================================================================
+
+func GoodCodeCaller() {
+	err := Find("x")
+	if CodeOf(err) == CodeNotFound {
+		println("not found")
+	}
+}
+
+func BadCodeCaller() {
+	err := Find("x") // want "missing errors.Is check for checkhelper/apperr.ErrNotFound" "missing errors.Is check for checkhelper/apperr.ErrGone"
+	if CodeOf(err) == CodeConflict {
+		println("conflict")
+	}
+}
@@ -0,0 +1,103 @@
+package caller
+
+import (
+	"errors"
+
+	"checkhelper/apperr"
+)
+
+func Load(id string) error { // want Load:`\[checkhelper/apperr.ErrNotFound, checkhelper/apperr.ErrGone, checkhelper/apperr.ValidationError, checkhelper/apperr.ErrConflict\]`
+	switch id {
+	case "":
+		return apperr.ErrNotFound
+	case "gone":
+		return apperr.ErrGone
+	case "invalid":
+		return &apperr.ValidationError{Field: "id"}
+	}
+	return apperr.ErrConflict
+}
+
+// GoodCaller covers every error through cross-package helpers.
+func GoodCaller() {
+	err := Load("x")
+	if apperr.IsNotFound(err) {
+		println("not found")
+	} else if apperr.IsValidation(err) {
+		println("invalid")
+	} else if apperr.HasCode(409, err) {
+		println("conflict")
+	}
+}
+
+// PartialCaller leaves ErrConflict unchecked.
+func PartialCaller() {
+	err := Load("x") // want "missing errors.Is check for checkhelper/apperr.ErrConflict"
+	if apperr.IsNotFound(err) || apperr.IsValidation(err) {
+		println("handled")
+	}
+}
+
+// MixedCaller combines helpers with errors.Is.
+func MixedCaller() {
+	err := Load("x")
+	switch {
+	case apperr.IsNotFound(err):
+		println("not found")
+	case apperr.IsValidation(err):
+		println("invalid")
+	case errors.Is(err, apperr.ErrConflict):
+		println("conflict")
+	}
+}
+
+// mapNotFound checks errors through a helper on its parameter, so callers
+// passing an error to it only need to handle the remaining errors.
+func mapNotFound(err error) error { // want mapNotFound:`\[param0:\[checkhelper/apperr.ErrNotFound,checkhelper/apperr.ErrGone\]\]`
+	if apperr.IsNotFound(err) {
+		return nil
+	}
+	return errors.New("unexpected")
+}
+
+func HelperInsideCallee() error {
+	err := Load("x") // want "missing errors.Is check for checkhelper/apperr.ValidationError" "missing errors.Is check for checkhelper/apperr.ErrConflict"
+	return mapNotFound(err)
+}
+
+// CodeCaller covers every error by switching on the code.
+func CodeCaller() {
+	err := Load("x")
+	if apperr.IsValidation(err) {
+		println("invalid")
+		return
+	}
+	switch apperr.CodeOf(err) {
+	case apperr.CodeNotFound:
+		println("not found")
+	case apperr.CodeConflict:
+		println("conflict")
+	}
+}
+
+// PartialCodeCaller compares one code only.
+func PartialCodeCaller() {
+	err := Load("x") // want "missing errors.Is check for checkhelper/apperr.ErrConflict"
+	if apperr.CodeNotFound == apperr.CodeOf(err) || apperr.IsValidation(err) {
+		println("handled")
+	}
+}
+
+// mapConflict compares the code of its parameter, so callers passing an error to it
+// only need to handle the remaining errors.
+func mapConflict(err error) error { // want mapConflict:`\[param0:\[checkhelper/apperr.ErrConflict\]\]`
+	if apperr.CodeOf(err) == apperr.CodeConflict {
+		return nil
+	}
+	return errors.New("unexpected")
+}
+
+func CodeInsideCallee() error {
+	err := Load("x") // want "missing errors.Is check for checkhelper/apperr.ErrNotFound" "missing errors.Is check for checkhelper/apperr.ErrGone" "missing errors.Is check for checkhelper/apperr.ValidationError"
+	return mapConflict(err)
+}
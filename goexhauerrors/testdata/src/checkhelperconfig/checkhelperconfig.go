@@ -0,0 +1,71 @@
+package checkhelperconfig
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`checkhelperconfig.ErrNotFound`
+var ErrTimeout = errors.New("timeout")    // want ErrTimeout:`checkhelperconfig.ErrTimeout`
+
+// IsNotFound is configured via -checkFunctions; it carries no directive.
+func IsNotFound(err error) bool {
+	return err != nil && err.Error() == "not found"
+}
+
+type Classifier struct{}
+
+// IsTimeout is a configured method helper.
+func (Classifier) IsTimeout(err error) bool {
+	return err != nil && err.Error() == "timeout"
+}
+
+// StatusOf is a configured code helper.
+func StatusOf(err error) int {
+	if err == nil {
+		return StatusOK
+	}
+	return StatusTimeout
+}
+
+const (
+	StatusOK      = 200
+	StatusTimeout = 504
+)
+
+func Get() error { // want Get:`\[checkhelperconfig.ErrNotFound, checkhelperconfig.ErrTimeout\]`
+	if true {
+		return ErrNotFound
+	}
+	return ErrTimeout
+}
+
+func GoodCaller(c Classifier) {
+	err := Get()
+	if IsNotFound(err) {
+		println("not found")
+	} else if c.IsTimeout(err) {
+		println("timeout")
+	}
+}
+
+func BadCaller() {
+	err := Get() // want "missing errors.Is check for checkhelperconfig.ErrTimeout"
+	if IsNotFound(err) {
+		println("not found")
+	}
+}
+
+func GoodStatusCaller() {
+	err := Get()
+	switch {
+	case IsNotFound(err):
+		println("not found")
+	case StatusOf(err) == StatusTimeout:
+		println("timeout")
+	}
+}
+
+func BadStatusCaller() {
+	err := Get() // want "missing errors.Is check for checkhelperconfig.ErrTimeout"
+	if StatusOf(err) == StatusOK || IsNotFound(err) {
+		println("ok")
+	}
+}
@@ -132,23 +132,22 @@ func GoodCallerErrorTyped() {
 
 // =============================================================================
 // Pattern 4: Custom constructor function (not errors.New directly)
-// This is a known limitation - custom constructors are not recognized.
+// Helpers of the package returning fresh errors are recognized.
 // =============================================================================
 
 func newSentinelError(msg string) error {
 	return errors.New(msg)
 }
 
-var ErrCustomInit = newSentinelError("custom initialized error")
+var ErrCustomInit = newSentinelError("custom initialized error") // want ErrCustomInit:`compositelit.ErrCustomInit`
 
-func GetCustomError() error {
+func GetCustomError() error { // want GetCustomError:`\[compositelit.ErrCustomInit\]`
 	return ErrCustomInit
 }
 
-// This is NOT detected - known limitation.
 // ErrCustomInit is created by a custom constructor, not errors.New or composite literal.
 func BadCallerCustomInit() {
-	err := GetCustomError()
+	err := GetCustomError() // want "missing errors.Is check for compositelit.ErrCustomInit"
 	if err != nil {
 		println(err.Error())
 	}
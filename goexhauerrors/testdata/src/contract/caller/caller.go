@@ -0,0 +1,17 @@
+package caller
+
+import (
+	"errors"
+
+	"contract"
+)
+
+// NOTE: caller imports no implementation of contract.Repository.
+// The declared contract is used anyway.
+
+func Find(repo contract.Repository) {
+	err := repo.Find("1") // want "missing errors.Is check for contract.ErrConflict"
+	if errors.Is(err, contract.ErrNotFound) {
+		println("not found")
+	}
+}
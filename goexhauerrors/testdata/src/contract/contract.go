@@ -0,0 +1,105 @@
+package contract
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")    // want ErrNotFound:`contract.ErrNotFound`
+var ErrConflict = errors.New("conflict")     // want ErrConflict:`contract.ErrConflict`
+var ErrUndeclared = errors.New("undeclared") // want ErrUndeclared:`contract.ErrUndeclared`
+
+// =============================================================================
+// Interface with declared error contracts
+// =============================================================================
+
+type Repository interface {
+	//goexhauerrors:returns ErrNotFound ErrConflict
+	Find(id string) error // want Find:`returns:\[contract.ErrNotFound,contract.ErrConflict\]` Find:`\[contract.ErrNotFound, contract.ErrConflict\]`
+
+	//goexhauerrors:returns ErrMissing // want "unknown error ErrMissing in goexhauerrors:returns directive"
+	Delete(id string) error // want Delete:`returns:\[\]`
+}
+
+// =============================================================================
+// Implementations are verified against the contract
+// =============================================================================
+
+// GoodRepo returns a subset of the contract.
+type GoodRepo struct{}
+
+func (r *GoodRepo) Find(id string) error { // want Find:`\[contract.ErrNotFound\]`
+	return ErrNotFound
+}
+
+func (r *GoodRepo) Delete(id string) error {
+	return nil
+}
+
+// BadRepo returns an error the contract does not declare.
+type BadRepo struct{}
+
+func (r *BadRepo) Find(id string) error { // want Find:`\[contract.ErrNotFound, contract.ErrUndeclared\]`
+	if id == "" {
+		return ErrNotFound
+	}
+	return ErrUndeclared // want "contract.ErrUndeclared is not declared by the goexhauerrors:returns contract of Repository.Find"
+}
+
+func (r *BadRepo) Delete(id string) error { // want Delete:`\[contract.ErrConflict\]` "contract.ErrConflict is not declared by the goexhauerrors:returns contract of Repository.Delete"
+	err := ErrConflict
+	return err
+}
+
+// =============================================================================
+// Callers use the contract, not the union of the implementations
+// =============================================================================
+
+func UseFind(repo Repository) {
+	err := repo.Find("1") // want "missing errors.Is check for contract.ErrNotFound" "missing errors.Is check for contract.ErrConflict"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func UseFindGood(repo Repository) {
+	err := repo.Find("1")
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	} else if errors.Is(err, ErrConflict) {
+		println("conflict")
+	}
+}
+
+func UseDelete(repo Repository) {
+	err := repo.Delete("1")
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// A contract covers the method's own errors; errors passed in still flow through
+// =============================================================================
+
+type Handler interface {
+	//goexhauerrors:returns ErrNotFound
+	Handle(cause error) error // want Handle:`returns:\[contract.ErrNotFound\]` Handle:`\[contract.ErrNotFound\]` Handle:`\[0\]`
+}
+
+type PassHandler struct{}
+
+func (h *PassHandler) Handle(cause error) error { // want Handle:`\[contract.ErrNotFound\]` Handle:`\[0\]`
+	if cause != nil {
+		return cause
+	}
+	return ErrNotFound
+}
+
+func UseHandle(h Handler) {
+	err := h.Handle(ErrConflict) // want "missing errors.Is check for contract.ErrNotFound" "missing errors.Is check for contract.ErrConflict"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func handleVia(h Handler) error { // want handleVia:`\[contract.ErrNotFound, contract.ErrConflict\]` handleVia:`\[call:0\]`
+	return h.Handle(ErrConflict)
+}
@@ -0,0 +1,14 @@
+package impl
+
+import "contract"
+
+// SQLRepo implements contract.Repository in another package and is verified there.
+type SQLRepo struct{} // want SQLRepo:`implements:\[contract.Repository\]`
+
+func (r *SQLRepo) Find(id string) (err error) { // want Find:`\[contract.ErrUndeclared\]`
+	return contract.ErrUndeclared // want "contract.ErrUndeclared is not declared by the goexhauerrors:returns contract of Repository.Find"
+}
+
+func (r *SQLRepo) Delete(id string) error {
+	return nil
+}
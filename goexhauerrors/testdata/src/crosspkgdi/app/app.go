@@ -0,0 +1,39 @@
+package app
+
+import (
+	"errors"
+	"log"
+
+	"crosspkgdi/domain"
+	"crosspkgdi/wire"
+)
+
+// NOTE: app imports infra only transitively (through wire).
+// The implementation is found through the ImplementsFact exported by infra when all
+// packages are analyzed together. go vet, which analyzes each package in its own
+// process, needs the implementation index.
+
+// BadFind does not check specific errors — should warn.
+func BadFind(id string) {
+	repo := wire.NewRepository()
+	_, err := repo.FindByID(id) // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
+	log.Println(err)
+}
+
+// GoodFind checks all errors — no warning.
+func GoodFind(id string) {
+	repo := wire.NewRepository()
+	_, err := repo.FindByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		log.Println("not found")
+	}
+}
+
+// BadRunInTx does not check errors from higher-order call — should warn.
+func BadRunInTx() {
+	repo := wire.NewRepository()
+	err := repo.RunInTx(func() error { // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
+		return domain.ErrNotFound
+	})
+	log.Println(err)
+}
@@ -0,0 +1,17 @@
+package cached
+
+import "crosspkgdi/infra"
+
+// NOTE: cached imports domain only transitively (through infra), and
+// CachedRepository has no methods of its own. It implements domain.Repository
+// through the methods promoted from the embedded infra.RepositoryImpl.
+
+// CachedRepository wraps the infra implementation.
+type CachedRepository struct { // want CachedRepository:`implements:\[crosspkgdi/domain.Repository\]`
+	*infra.RepositoryImpl
+}
+
+// NewCachedRepository returns a repository backed by infra.
+func NewCachedRepository() *CachedRepository {
+	return &CachedRepository{RepositoryImpl: &infra.RepositoryImpl{}}
+}
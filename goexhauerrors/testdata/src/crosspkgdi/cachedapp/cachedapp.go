@@ -0,0 +1,38 @@
+package cachedapp
+
+import (
+	"errors"
+	"log"
+
+	"crosspkgdi/cached"
+	"crosspkgdi/domain"
+)
+
+// NOTE: cachedapp imports infra only transitively (through cached).
+// The implementation is found through the ImplementsFact exported by cached,
+// whose methods are promoted from infra.RepositoryImpl.
+
+// BadFind does not check specific errors — should warn.
+func BadFind(repo domain.Repository, id string) {
+	_, err := repo.FindByID(id) // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
+	log.Println(err)
+}
+
+// GoodFind checks all errors — no warning.
+func GoodFind(repo domain.Repository, id string) {
+	_, err := repo.FindByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		log.Println("not found")
+	}
+}
+
+// BadLocalType calls through a type declared inside the function, which
+// implements domain.Repository through embedding — should warn.
+func BadLocalType(id string) {
+	type tracing struct {
+		*cached.CachedRepository
+	}
+	var repo domain.Repository = tracing{cached.NewCachedRepository()}
+	_, err := repo.FindByID(id) // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
+	log.Println(err)
+}
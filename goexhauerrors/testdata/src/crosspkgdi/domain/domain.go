@@ -14,7 +14,7 @@ func (e *ValidationError) Error() string {
 
 // Validate is a regular exported function (not an interface method).
 // Used to test that diagnostics are not duplicated when a function body
-// contains both regular calls and interface method calls with global store misses.
+// contains both regular calls and interface method calls resolved through the index.
 func Validate(id string) error { // want Validate:`\[crosspkgdi/domain.ErrNotFound\]`
 	if id == "" {
 		return ErrNotFound
@@ -3,7 +3,7 @@ package infra
 import "crosspkgdi/domain"
 
 // RepositoryImpl implements domain.Repository.
-type RepositoryImpl struct{}
+type RepositoryImpl struct{} // want RepositoryImpl:`implements:\[crosspkgdi/domain.Repository\]`
 
 func (r *RepositoryImpl) FindByID(id string) (string, error) { // want FindByID:`\[crosspkgdi/domain.ErrNotFound\]`
 	if id == "" {
@@ -8,7 +8,9 @@ import (
 )
 
 // NOTE: usecase does NOT import infra — this is a real DI pattern.
-// The linter should still detect that Repository.FindByID can return ErrNotFound
+// Facts cannot reach usecase from infra, so this package is analyzed with
+// an implementation index written by a previous run over infra.
+// The linter should then detect that Repository.FindByID can return ErrNotFound
 // and Repository.Save can return ValidationError.
 
 type GetUseCase struct {
@@ -38,8 +40,7 @@ func (uc *GetUseCase) BadCallerSave(id string, value string) {
 }
 
 // MixedCaller calls both a regular function and an interface method without checking errors.
-// This tests that each diagnostic is reported exactly once, even when deferred re-analysis
-// re-walks the function body (the regular call's error must not be duplicated).
+// This tests that each diagnostic is reported exactly once.
 func (uc *GetUseCase) MixedCaller(id string) {
 	err := domain.Validate(id) // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
 	log.Println(err)
@@ -88,3 +89,26 @@ func (uc *GetUseCase) GoodCallerRunInTx() {
 		log.Println("not found")
 	}
 }
+
+// RepositoryCloser inherits the methods of domain.Repository, which are found in the
+// index under domain.Repository
+type RepositoryCloser interface {
+	domain.Repository
+	Close()
+}
+
+func BadCallerEmbeddedInterface(rc RepositoryCloser) {
+	err := rc.Save("id", "value") // want "missing errors.Is check for crosspkgdi/domain.ValidationError"
+	log.Println(err)
+	rc.Close()
+}
+
+// Handler calls the methods of domain.Repository promoted through embedding
+type Handler struct {
+	domain.Repository
+}
+
+func (h *Handler) BadCallerEmbeddedStruct(id string) {
+	_, err := h.FindByID(id) // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
+	log.Println(err)
+}
@@ -0,0 +1,40 @@
+package usecasenoindex
+
+import (
+	"log"
+
+	"crosspkgdi/domain"
+)
+
+// NOTE: like usecase, this package imports domain but NOT infra, and it is analyzed
+// in a single run without an implementation index.
+// Facts cannot reach it from infra, so the interface methods of domain.Repository have
+// no known errors here and calls through them are NOT reported. Before implementation
+// facts and the index replaced the process-wide stores, a single run reported these
+// calls whenever infra happened to be analyzed first; that order-dependent detection
+// was removed. Use -writeImplIndex and -implIndex to report them (see usecase).
+
+type GetUseCase struct {
+	repo domain.Repository
+}
+
+// BadCaller is reported only with an implementation index.
+func (uc *GetUseCase) BadCaller(id string) {
+	_, err := uc.repo.FindByID(id)
+	log.Println(err)
+}
+
+// BadCallerSave is reported only with an implementation index.
+func (uc *GetUseCase) BadCallerSave(id string, value string) {
+	err := uc.repo.Save(id, value)
+	log.Println(err)
+}
+
+// MixedCaller still reports the regular function, whose errors reach this package
+// through the import of domain.
+func (uc *GetUseCase) MixedCaller(id string) {
+	err := domain.Validate(id) // want "missing errors.Is check for crosspkgdi/domain.ErrNotFound"
+	log.Println(err)
+	_, err = uc.repo.FindByID(id)
+	log.Println(err)
+}
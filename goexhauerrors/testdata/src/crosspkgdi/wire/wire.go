@@ -0,0 +1,11 @@
+package wire
+
+import (
+	"crosspkgdi/domain"
+	"crosspkgdi/infra"
+)
+
+// NewRepository hides the implementation behind the domain interface.
+func NewRepository() domain.Repository {
+	return &infra.RepositoryImpl{}
+}
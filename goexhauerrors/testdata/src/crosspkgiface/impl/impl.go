@@ -11,13 +11,13 @@ import (
 // Service implementations (DoWork returns different errors)
 // =============================================================================
 
-type ServiceImplA struct{}
+type ServiceImplA struct{} // want ServiceImplA:`implements:\[crosspkgiface/iface.Service\]`
 
 func (s *ServiceImplA) DoWork() error { // want DoWork:`\[crosspkgiface/iface.ErrIfaceA\]`
 	return iface.ErrIfaceA
 }
 
-type ServiceImplB struct{}
+type ServiceImplB struct{} // want ServiceImplB:`implements:\[crosspkgiface/iface.Service\]`
 
 func (s *ServiceImplB) DoWork() error { // want DoWork:`\[crosspkgiface/iface.ErrIfaceB\]`
 	return iface.ErrIfaceB
@@ -27,13 +27,13 @@ func (s *ServiceImplB) DoWork() error { // want DoWork:`\[crosspkgiface/iface.Er
 // Transformer implementations (all propagate error param)
 // =============================================================================
 
-type TransformImplA struct{}
+type TransformImplA struct{} // want TransformImplA:`implements:\[crosspkgiface/iface.Transformer\]`
 
 func (t *TransformImplA) Transform(err error) error { // want Transform:`\[0\]`
 	return err
 }
 
-type TransformImplB struct{}
+type TransformImplB struct{} // want TransformImplB:`implements:\[crosspkgiface/iface.Transformer\]`
 
 func (t *TransformImplB) Transform(err error) error { // want Transform:`\[wrapped:0\]`
 	return fmt.Errorf("transformed: %w", err)
@@ -43,7 +43,7 @@ func (t *TransformImplB) Transform(err error) error { // want Transform:`\[wrapp
 // Mapper implementations (all check ErrIfaceA)
 // =============================================================================
 
-type MapImplA struct{}
+type MapImplA struct{} // want MapImplA:`implements:\[crosspkgiface/iface.Mapper\]`
 
 func (m *MapImplA) Map(err error) error { // want Map:`\[param0:\[crosspkgiface/iface.ErrIfaceA\]\]`
 	if errors.Is(err, iface.ErrIfaceA) {
@@ -52,7 +52,7 @@ func (m *MapImplA) Map(err error) error { // want Map:`\[param0:\[crosspkgiface/
 	return errors.New("not handled")
 }
 
-type MapImplB struct{}
+type MapImplB struct{} // want MapImplB:`implements:\[crosspkgiface/iface.Mapper\]`
 
 func (m *MapImplB) Map(err error) error { // want Map:`\[param0:\[crosspkgiface/iface.ErrIfaceA\]\]`
 	if errors.Is(err, iface.ErrIfaceA) {
@@ -25,14 +25,15 @@ func NewHandler(uc *usecase.UpdateUseCase) *Handler {
 
 // Update handles the update request.
 // ErrTableNotFound is NOT propagated because fmt.Errorf uses %v (not %w) for err.
+// It is reported where it is flattened, in the default case.
 func (h *Handler) Update(ctx context.Context, tableID string) error { // want Update:`\[crosspkgmethod/presentation.ErrNotFound, crosspkgmethod/presentation.ErrInternal\]`
-	err := h.updateUC.Execute(ctx, tableID) // want "missing errors.Is check for crosspkgmethod/errors.ErrTableNotFound"
+	err := h.updateUC.Execute(ctx, tableID)
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrNotFound):
 			return fmt.Errorf("%w: %v", ErrNotFound, err)
 		default:
-			return fmt.Errorf("%w: %v", ErrInternal, err)
+			return fmt.Errorf("%w: %v", ErrInternal, err) // want "crosspkgmethod/errors.ErrTableNotFound is flattened by %v and can no longer be matched by callers"
 		}
 	}
 	return nil
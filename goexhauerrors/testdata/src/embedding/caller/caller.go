@@ -0,0 +1,51 @@
+package caller
+
+import (
+	"errors"
+
+	"embedding"
+)
+
+var ErrClosed = errors.New("closed") // want ErrClosed:`embedding/caller.ErrClosed`
+
+// Handler embeds an imported interface
+type Handler struct {
+	embedding.Repository
+}
+
+// ReadCloser embeds an imported interface in an interface of this package
+type ReadCloser interface {
+	embedding.Reader
+	Close() error // want Close:`\[embedding/caller.ErrClosed\]`
+}
+
+// Stream implements ReadCloser in this package
+type Stream struct{} // want Stream:`implements:\[embedding.Reader\]`
+
+func (Stream) Read() error { // want Read:`\[embedding.ErrEOF\]`
+	return embedding.ErrEOF
+}
+
+func (Stream) Close() error { // want Close:`\[embedding/caller.ErrClosed\]`
+	return ErrClosed
+}
+
+// Cache implements the embedded interface in this package
+type Cache struct{} // want Cache:`implements:\[embedding.Repository\]`
+
+func (Cache) Get(id int) error { // want Get:`\[embedding/caller.ErrClosed\]`
+	return ErrClosed
+}
+
+// The promoted method resolves to the implementations of this package too
+func (h Handler) Serve() {
+	err := h.Get(1) // want "missing errors.Is check for embedding.ErrNotFound" "missing errors.Is check for embedding/caller.ErrClosed"
+	println(err)
+}
+
+func UseReadCloser(rc ReadCloser) {
+	err := rc.Read() // want "missing errors.Is check for embedding.ErrEOF"
+	println(err)
+	err = rc.Close() // want "missing errors.Is check for embedding/caller.ErrClosed"
+	println(err)
+}
@@ -0,0 +1,86 @@
+package embedding
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`embedding.ErrNotFound`
+var ErrEOF = errors.New("eof")            // want ErrEOF:`embedding.ErrEOF`
+var ErrFull = errors.New("full")          // want ErrFull:`embedding.ErrFull`
+
+type Repository interface {
+	Get(id int) error // want Get:`\[embedding.ErrNotFound\]`
+}
+
+type Reader interface {
+	Read() error // want Read:`\[embedding.ErrEOF\]`
+}
+
+// ReadWriter inherits Read from Reader
+type ReadWriter interface {
+	Reader
+	Write() error // want Write:`\[embedding.ErrFull\]`
+}
+
+type UserRepo struct{}
+
+func (*UserRepo) Get(id int) error { // want Get:`\[embedding.ErrNotFound\]`
+	return ErrNotFound
+}
+
+type File struct{}
+
+func (*File) Read() error { // want Read:`\[embedding.ErrEOF\]`
+	return ErrEOF
+}
+
+func (*File) Write() error { // want Write:`\[embedding.ErrFull\]`
+	return ErrFull
+}
+
+// Service embeds Repository and calls it through the promoted method
+type Service struct {
+	Repository
+}
+
+// Nested embeds Service, which embeds Repository
+type Nested struct {
+	*Service
+}
+
+// =============================================================================
+// Test 1: Methods promoted from an interface embedded in a struct
+// =============================================================================
+
+func (s *Service) LoadUnchecked() {
+	err := s.Get(1) // want "missing errors.Is check for embedding.ErrNotFound"
+	println(err)
+}
+
+func (s *Service) LoadChecked() {
+	err := s.Get(1)
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+func (n Nested) LoadNested() {
+	err := n.Get(1) // want "missing errors.Is check for embedding.ErrNotFound"
+	println(err)
+}
+
+// =============================================================================
+// Test 2: Methods inherited from an embedded interface
+// =============================================================================
+
+func ReadUnchecked(rw ReadWriter) {
+	err := rw.Read() // want "missing errors.Is check for embedding.ErrEOF"
+	println(err)
+	err = rw.Write() // want "missing errors.Is check for embedding.ErrFull"
+	println(err)
+}
+
+func ReadChecked(rw ReadWriter) {
+	err := rw.Read()
+	if errors.Is(err, ErrEOF) {
+		println("eof")
+	}
+}
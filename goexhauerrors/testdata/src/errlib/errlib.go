@@ -0,0 +1,263 @@
+package errlib
+
+import (
+	"fmt"
+
+	crdberrors "github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/markers"
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/xerrors"
+)
+
+// =============================================================================
+// Sentinels created with third-party constructors
+// =============================================================================
+
+var ErrNotFound = pkgerrors.New("not found")              // want ErrNotFound:`errlib.ErrNotFound`
+var ErrConflict = crdberrors.New("conflict")              // want ErrConflict:`errlib.ErrConflict`
+var ErrTimeout = crdberrors.Newf("timeout after %ds", 30) // want ErrTimeout:`errlib.ErrTimeout`
+var ErrClosed = xerrors.New("closed")                     // want ErrClosed:`errlib.ErrClosed`
+var ErrLegacy = pkgerrors.Errorf("legacy %s", "error")    // want ErrLegacy:`errlib.ErrLegacy`
+
+type ValidationError struct { // want ValidationError:`errlib.ValidationError`
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid " + e.Field
+}
+
+// =============================================================================
+// Wrapping APIs carry the wrapped error into the function's errors
+// =============================================================================
+
+func FindWrap() error { // want FindWrap:`\[errlib.ErrNotFound\]`
+	return pkgerrors.Wrap(ErrNotFound, "find")
+}
+
+func FindWrapf(id int) error { // want FindWrapf:`\[errlib.ErrNotFound\]`
+	return pkgerrors.Wrapf(ErrNotFound, "find %d", id)
+}
+
+func SaveWithStack() error { // want SaveWithStack:`\[errlib.ErrConflict\]`
+	return pkgerrors.WithStack(ErrConflict)
+}
+
+func SaveWithHint() error { // want SaveWithHint:`\[errlib.ErrConflict\]`
+	return crdberrors.WithHint(ErrConflict, "retry later")
+}
+
+func WaitNewf() error { // want WaitNewf:`\[errlib.ErrTimeout\]`
+	return crdberrors.Newf("wait: %w", ErrTimeout)
+}
+
+func CloseXerrors() error { // want CloseXerrors:`\[errlib.ErrClosed\]`
+	return xerrors.Errorf("close: %w", ErrClosed)
+}
+
+func Validate() error { // want Validate:`\[errlib.ValidationError\]`
+	return crdberrors.Wrapf(&ValidationError{Field: "name"}, "validate %s", "name")
+}
+
+// NotWrapped uses %v, so ErrTimeout is not carried.
+func NotWrapped() error {
+	return crdberrors.Newf("wait: %v", ErrTimeout)
+}
+
+// =============================================================================
+// Parameter flow through wrapping APIs
+// =============================================================================
+
+func Annotate(err error) error { // want Annotate:`\[wrapped:0\]`
+	return pkgerrors.Wrap(err, "annotated")
+}
+
+func AnnotateBad() {
+	err := Annotate(ErrNotFound) // want "missing errors.Is check for errlib.ErrNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Checking APIs are equivalent to errors.Is / errors.As
+// =============================================================================
+
+func PkgErrorsIsGood() {
+	err := FindWrap()
+	if pkgerrors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+func CockroachIsGood() {
+	err := SaveWithStack()
+	if crdberrors.Is(err, ErrConflict) {
+		println("conflict")
+	}
+}
+
+func MarkersIsGood() {
+	err := WaitNewf()
+	if markers.Is(err, ErrTimeout) {
+		println("timeout")
+	}
+}
+
+func XerrorsIsGood() {
+	err := CloseXerrors()
+	if xerrors.Is(err, ErrClosed) {
+		println("closed")
+	}
+}
+
+func CockroachAsGood() {
+	err := Validate()
+	var ve *ValidationError
+	if crdberrors.As(err, &ve) {
+		println(ve.Field)
+	}
+}
+
+func Multi(flag int) error { // want Multi:`\[errlib.ErrNotFound, errlib.ErrConflict\]`
+	if flag == 0 {
+		return pkgerrors.WithStack(ErrNotFound)
+	}
+	return pkgerrors.WithStack(ErrConflict)
+}
+
+// IsAny covers every reference passed to it.
+func IsAnyGood() {
+	err := Multi(0)
+	if crdberrors.IsAny(err, ErrNotFound, ErrConflict) {
+		println("not found or conflict")
+	}
+}
+
+func IsAnyPartialBad() {
+	err := Multi(0) // want "missing errors.Is check for errlib.ErrConflict"
+	if markers.IsAny(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+func UncheckedBad() {
+	err := FindWrap() // want "missing errors.Is check for errlib.ErrNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Cause unwraps to the root error for direct comparisons
+// =============================================================================
+
+func CauseCompareGood() {
+	err := FindWrap()
+	if pkgerrors.Cause(err) == ErrNotFound {
+		println("not found")
+	}
+}
+
+func CauseSwitchGood() {
+	err := Multi(1)
+	switch pkgerrors.Cause(err) {
+	case ErrNotFound:
+		println("not found")
+	case ErrConflict:
+		println("conflict")
+	}
+}
+
+func CauseSwitchPartialBad() {
+	err := Multi(1) // want "missing errors.Is check for errlib.ErrConflict"
+	switch pkgerrors.Cause(err) {
+	case ErrNotFound:
+		println("not found")
+	}
+}
+
+// Cause only unwraps the wrappers of pkg/errors, which have a Cause method: an error
+// wrapped with fmt.Errorf %w is not the root cause, so comparing the cause does not check it.
+func FindErrorf() error { // want FindErrorf:`\[errlib.ErrNotFound\]`
+	return fmt.Errorf("find: %w", ErrNotFound)
+}
+
+func CauseCompareErrorfBad() {
+	err := FindErrorf() // want "missing errors.Is check for errlib.ErrNotFound"
+	if pkgerrors.Cause(err) == ErrNotFound {
+		println("not found")
+	}
+}
+
+func CauseSwitchErrorfBad() {
+	err := FindErrorf() // want "missing errors.Is check for errlib.ErrNotFound"
+	switch pkgerrors.Cause(err) {
+	case ErrNotFound:
+		println("not found")
+	}
+}
+
+func FindErrorfWithStack() error { // want FindErrorfWithStack:`\[errlib.ErrNotFound\]`
+	return pkgerrors.WithStack(fmt.Errorf("find: %w", ErrNotFound))
+}
+
+func CauseSwitchWithStackBad() {
+	err := FindErrorfWithStack() // want "missing errors.Is check for errlib.ErrNotFound"
+	switch pkgerrors.Cause(err) {
+	case ErrNotFound:
+		println("not found")
+	}
+}
+
+// cockroachdb's Cause unwraps every wrapper.
+func CockroachCauseErrorfGood() {
+	err := FindErrorf()
+	if crdberrors.Cause(err) == ErrNotFound {
+		println("not found")
+	}
+}
+
+// The result of Cause is the root cause, which is not wrapped.
+func Root() error { // want Root:`\[errlib.ErrNotFound\]`
+	err := FindWrap()
+	return pkgerrors.Cause(err)
+}
+
+func RootCompareGood() {
+	err := Root()
+	if err == ErrNotFound {
+		println("not found")
+	}
+}
+
+// A parameter passed to Cause flows to the result as it arrives, not wrapped.
+func RootOf(err error) error { // want RootOf:`\[0\]`
+	return pkgerrors.Cause(err)
+}
+
+func RootOfCompareGood() {
+	err := RootOf(ErrNotFound)
+	if err == ErrNotFound {
+		println("not found")
+	}
+}
+
+// =============================================================================
+// Returning through a wrapping API is propagation
+// =============================================================================
+
+func PropagateWrap() error {
+	err := FindWrap()
+	return pkgerrors.Wrap(err, "propagate")
+}
+
+func PropagateWrapf() error {
+	err := Multi(0)
+	return crdberrors.Wrapf(err, "propagate %d", 0)
+}
+
+func PropagateFmtErrorf() error {
+	err := FindWrap()
+	return fmt.Errorf("propagate: %w", err)
+}
@@ -0,0 +1,42 @@
+package errlibconfig
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`errlibconfig.ErrNotFound`
+
+// Annotate is configured via -wrapperFunctions as carrying its second argument.
+func Annotate(msg string, err error) error {
+	return errors.New(msg + ": " + err.Error())
+}
+
+// Matches is configured via -checkerFunctions as an errors.Is equivalent.
+func Matches(err, target error) bool {
+	return err.Error() == target.Error()
+}
+
+func Find() error { // want Find:`\[errlibconfig.ErrNotFound\]`
+	return Annotate("find", ErrNotFound)
+}
+
+func Wrap(err error) error { // want Wrap:`\[wrapped:0\]`
+	return Annotate("wrap", err)
+}
+
+func MatchesGood() {
+	err := Find()
+	if Matches(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+func UncheckedBad() {
+	err := Find() // want "missing errors.Is check for errlibconfig.ErrNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func PropagateGood() error {
+	err := Find()
+	return Annotate("propagate", err)
+}
@@ -0,0 +1,36 @@
+package caller
+
+import (
+	"errors"
+
+	"errresult"
+)
+
+// Load propagates the imported domain error interface
+func Load(id string) (*errresult.User, errresult.Error) { // want Load:`\[errresult.CodeError\]`
+	return errresult.Get(id)
+}
+
+func LoadUnchecked() {
+	_, err := Load("id") // want "missing errors.Is check for errresult.CodeError"
+	println(err)
+}
+
+func DoChecked() {
+	if err := errresult.Do(true); err != nil {
+		var codeErr *errresult.CodeError
+		if errors.As(err, &codeErr) {
+			println(codeErr.Code())
+		}
+	}
+}
+
+func DoUnchecked() {
+	err := errresult.Do(false) // want "missing errors.Is check for errresult.CodeError"
+	println(err)
+}
+
+func UseConstructed() {
+	e := errresult.NewCodeError(404)
+	println(e.Code())
+}
@@ -0,0 +1,152 @@
+package errresult
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`errresult.ErrNotFound`
+
+// Error is a domain error interface embedding error
+type Error interface { // want Error:`errresult.Error`
+	error
+	Code() int
+}
+
+// CodeError implements Error
+type CodeError struct { // want CodeError:`errresult.CodeError`
+	code int
+}
+
+func (e *CodeError) Error() string { return "code error" }
+func (e *CodeError) Code() int     { return e.code }
+
+type User struct{}
+
+// =============================================================================
+// Domain error interface results
+// =============================================================================
+
+// Get returns the domain error interface
+func Get(id string) (*User, Error) { // want Get:`\[errresult.CodeError\]`
+	if id == "" {
+		return nil, &CodeError{code: 404}
+	}
+	return &User{}, nil
+}
+
+func GetUnchecked() {
+	_, err := Get("id") // want "missing errors.Is check for errresult.CodeError"
+	println(err)
+}
+
+func GetChecked() {
+	_, err := Get("id")
+	var codeErr *CodeError
+	if errors.As(err, &codeErr) {
+		println(codeErr.Code())
+	}
+}
+
+// GetPropagated propagates the domain error interface
+func GetPropagated(id string) (*User, Error) { // want GetPropagated:`\[errresult.CodeError\]`
+	u, err := Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// =============================================================================
+// Concrete error pointer results
+// =============================================================================
+
+// Do returns a concrete error pointer
+func Do(fail bool) *CodeError { // want Do:`\[errresult.CodeError\]` Do:`nilable:\[0\]`
+	if fail {
+		return &CodeError{code: 500}
+	}
+	return nil
+}
+
+func DoUnchecked() {
+	err := Do(true) // want "missing errors.Is check for errresult.CodeError"
+	println(err)
+}
+
+func DoChecked() {
+	err := Do(true)
+	var codeErr *CodeError
+	if errors.As(err, &codeErr) {
+		println(codeErr.Code())
+	}
+}
+
+// =============================================================================
+// Typed nil returned as error
+// =============================================================================
+
+// DoAsError returns the *CodeError of Do, which is a non-nil error even when Do returns nil
+func DoAsError(fail bool) error { // want DoAsError:`\[errresult.CodeError\]`
+	return Do(fail) // want `\*CodeError returned as error is not nil when the pointer is nil`
+}
+
+// DoVarAsError returns a *CodeError variable that is nil unless fail is set
+func DoVarAsError(fail bool) error { // want DoVarAsError:`\[errresult.CodeError\]`
+	var err *CodeError
+	if fail {
+		err = &CodeError{code: 500}
+	}
+	return err // want `\*CodeError returned as error is not nil when the pointer is nil`
+}
+
+// DoLiteralAsError returns a pointer that is never nil
+func DoLiteralAsError() error { // want DoLiteralAsError:`\[errresult.CodeError\]`
+	err := &CodeError{code: 500}
+	return err
+}
+
+// DoCheckedAsError converts the result of Do only when it is not nil
+func DoCheckedAsError(fail bool) error { // want DoCheckedAsError:`\[errresult.CodeError\]`
+	if err := Do(fail); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DoAsDomainError returns the *CodeError of Do as the domain error interface
+func DoAsDomainError(fail bool) Error { // want DoAsDomainError:`\[errresult.CodeError\]`
+	return Do(fail) // want `\*CodeError returned as Error is not nil when the pointer is nil`
+}
+
+// NewCodeError is a constructor, which never returns nil
+func NewCodeError(code int) *CodeError { // want NewCodeError:`\[errresult.CodeError\]`
+	return &CodeError{code: code}
+}
+
+// ConstructedAsError returns the result of a constructor that never returns nil
+func ConstructedAsError() error { // want ConstructedAsError:`\[errresult.CodeError\]`
+	return NewCodeError(400)
+}
+
+// UseConstructed uses the error built by a constructor, which never returns nil and is
+// not a call failing with it
+func UseConstructed() {
+	e := NewCodeError(404)
+	println(e.Code())
+}
+
+// GetWithDetail returns a concrete error pointer next to the error interface
+func GetWithDetail(id string) (*CodeError, error) { // want GetWithDetail:`\[errresult.CodeError, errresult.ErrNotFound\]` GetWithDetail:`nilable:\[0\]`
+	if id == "" {
+		return &CodeError{code: 400}, nil
+	}
+	return nil, ErrNotFound
+}
+
+func GetWithDetailUnchecked() {
+	detail, err := GetWithDetail("id") // want "missing errors.Is check for errresult.CodeError" "missing errors.Is check for errresult.ErrNotFound"
+	println(detail, err)
+}
+
+// FindUser returns the sentinel through the error interface
+func FindUser() error { // want FindUser:`\[errresult.ErrNotFound\]`
+	return ErrNotFound
+}
@@ -0,0 +1,62 @@
+package errsetdiff
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrTimeout  = errors.New("timeout")
+	ErrGone     = errors.New("gone")
+)
+
+// Find started returning ErrTimeout since the baseline.
+func Find(id string) error { // want "Find returns errors added since baseline: errsetdiff.ErrTimeout"
+	if id == "" {
+		return ErrNotFound
+	}
+	return ErrTimeout
+}
+
+// Delete no longer returns ErrGone.
+func Delete(id string) error { // want "Delete no longer returns errors since baseline: errsetdiff.ErrGone"
+	if id == "" {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Unchanged returns the same errors as in the baseline.
+func Unchanged() error {
+	return ErrNotFound
+}
+
+// Added did not exist in the baseline and is not reported.
+func Added() error {
+	return ErrGone
+}
+
+type Store struct{}
+
+// Get swapped ErrNotFound for ErrGone.
+func (s *Store) Get(id string) error { // want "Store.Get returns errors added since baseline: errsetdiff.ErrGone" "Store.Get no longer returns errors since baseline: errsetdiff.ErrNotFound"
+	if id == "" {
+		return ErrGone
+	}
+	return nil
+}
+
+type Repository interface {
+	Save(id string) error // want "Repository.Save returns errors added since baseline: errsetdiff.ErrTimeout"
+}
+
+type repo struct{}
+
+func (r *repo) Save(id string) error {
+	if id == "" {
+		return ErrNotFound
+	}
+	return ErrTimeout
+}
+
+func unexported() error {
+	return ErrTimeout
+}
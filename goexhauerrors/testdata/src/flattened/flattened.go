@@ -0,0 +1,72 @@
+package flattened
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`flattened.ErrNotFound`
+var ErrConflict = errors.New("conflict")  // want ErrConflict:`flattened.ErrConflict`
+
+func Find() error { // want Find:`\[flattened.ErrNotFound\]`
+	return ErrNotFound
+}
+
+func Save(flag bool) error { // want Save:`\[flattened.ErrNotFound, flattened.ErrConflict\]`
+	if flag {
+		return ErrNotFound
+	}
+	return ErrConflict
+}
+
+// %v loses the error chain.
+func FlattenV() error {
+	err := Find()
+	return fmt.Errorf("find failed: %v", err) // want "flattened.ErrNotFound is flattened by %v and can no longer be matched by callers"
+}
+
+// %s loses the error chain as well.
+func FlattenS() error {
+	err := Find()
+	return fmt.Errorf("find failed: %s", err) // want "flattened.ErrNotFound is flattened by %s and can no longer be matched by callers"
+}
+
+// Flags are part of the reported verb.
+func FlattenPlusV(id int) error {
+	err := Find()
+	return fmt.Errorf("find %d failed: %+v", id, err) // want "flattened.ErrNotFound is flattened by %\\+v and can no longer be matched by callers"
+}
+
+// Only errors that are still unchecked are reported.
+func FlattenPartial() error {
+	err := Save(true)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return fmt.Errorf("save failed: %v", err) // want "flattened.ErrConflict is flattened by %v and can no longer be matched by callers"
+}
+
+// Translating a narrowed error into another one is intentional.
+func TranslateGood() error {
+	err := Save(true)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return fmt.Errorf("missing: %v", err)
+	case errors.Is(err, ErrConflict):
+		return fmt.Errorf("conflict: %v", err)
+	}
+	return nil
+}
+
+func WrapGood() error {
+	err := Find()
+	return fmt.Errorf("find failed: %w", err)
+}
+
+// Flattening is only reported when the error is returned.
+func LogOnlyBad() {
+	err := Find() // want "missing errors.Is check for flattened.ErrNotFound"
+	if err != nil {
+		println(fmt.Errorf("find failed: %v", err).Error())
+	}
+}
@@ -0,0 +1,20 @@
+package caller
+
+import (
+	"errors"
+
+	"funcfield"
+)
+
+// Field facts are exported, so callers in other packages see them as well.
+func UseHooks(h *funcfield.Hooks) {
+	err := h.OnLoad() // want "missing errors.Is check for funcfield.ErrLoad"
+	if errors.Is(err, funcfield.ErrSave) {
+		println("unrelated")
+	}
+}
+
+// RunHooks returns the errors of a field declared in another package
+func RunHooks(h *funcfield.Hooks) error { // want RunHooks:`\[funcfield.ErrLoad\]`
+	return h.OnLoad()
+}
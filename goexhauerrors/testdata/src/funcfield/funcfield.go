@@ -0,0 +1,132 @@
+package funcfield
+
+import "errors"
+
+var ErrSave = errors.New("save")   // want ErrSave:`funcfield.ErrSave`
+var ErrLoad = errors.New("load")   // want ErrLoad:`funcfield.ErrLoad`
+var ErrRoute = errors.New("route") // want ErrRoute:`funcfield.ErrRoute`
+
+type Service struct{}
+
+func (s *Service) Load() error { // want Load:`\[funcfield.ErrLoad\]`
+	return ErrLoad
+}
+
+// =============================================================================
+// Test 1: Closure stored in a struct field
+// =============================================================================
+
+type Store struct {
+	onSave func() error // want onSave:`\[funcfield.ErrSave\]`
+}
+
+func (st *Store) Init() {
+	st.onSave = func() error {
+		return ErrSave
+	}
+}
+
+func (st *Store) Save() {
+	err := st.onSave() // want "missing errors.Is check for funcfield.ErrSave"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func (st *Store) SaveGood() {
+	err := st.onSave()
+	if errors.Is(err, ErrSave) {
+		println("save failed")
+	}
+}
+
+// =============================================================================
+// Test 2: Method value in a struct literal
+// =============================================================================
+
+type Hooks struct {
+	OnLoad func() error // want OnLoad:`\[funcfield.ErrLoad\]`
+}
+
+func NewHooks(svc *Service) *Hooks {
+	return &Hooks{OnLoad: svc.Load}
+}
+
+func UseHooks(h *Hooks) {
+	err := h.OnLoad() // want "missing errors.Is check for funcfield.ErrLoad"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Test 3: Method value assigned to a variable
+// =============================================================================
+
+func UseMethodValue(svc *Service) {
+	fn := svc.Load // want fn:`\[funcfield.ErrLoad\]`
+	err := fn()    // want "missing errors.Is check for funcfield.ErrLoad"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Test 4: Handler registered through a function that stores it in a field
+// =============================================================================
+
+type Router struct {
+	handlers map[string]func() error // want handlers:`\[funcfield.ErrRoute\]`
+}
+
+func (r *Router) Handle(path string, h func() error) {
+	r.handlers[path] = h
+}
+
+func Register(r *Router) {
+	r.Handle("/x", func() error {
+		return ErrRoute
+	})
+}
+
+func (r *Router) Serve(path string) {
+	err := r.handlers[path]() // want "missing errors.Is check for funcfield.ErrRoute"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// Map literal keys are not fields and get no facts
+func UseMapLiteral(key string, h func() error) map[string]func() error {
+	return map[string]func() error{key: h}
+}
+
+// =============================================================================
+// Test 5: Function values called in a return statement
+// =============================================================================
+
+func (st *Store) Run() error { // want Run:`\[funcfield.ErrSave\]`
+	return st.onSave()
+}
+
+func RunHooks(h *Hooks) error { // want RunHooks:`\[funcfield.ErrLoad\]`
+	return h.OnLoad()
+}
+
+func (h Hooks) Run() error { // want Run:`\[funcfield.ErrLoad\]`
+	return h.OnLoad()
+}
+
+func (r *Router) Dispatch(path string) error { // want Dispatch:`\[funcfield.ErrRoute\]`
+	return r.handlers[path]()
+}
+
+func RunMethodValue(svc *Service) error { // want RunMethodValue:`\[funcfield.ErrLoad\]`
+	fn := svc.Load // want fn:`\[funcfield.ErrLoad\]`
+	return fn()
+}
+
+func UseRun(st *Store) {
+	err := st.Run() // want "missing errors.Is check for funcfield.ErrSave"
+	println(err)
+}
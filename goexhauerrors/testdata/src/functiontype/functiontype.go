@@ -175,17 +175,17 @@ func TestMapErrorOKAssignGood() error {
 }
 
 // =============================================================================
-// Test 10: fmt.Errorf with %v does NOT propagate (unlike %w)
+// Test 10: fmt.Errorf with %v does NOT propagate (unlike %w) and is reported at the fmt.Errorf site
 // =============================================================================
 
 func TestFmtErrorfWithVHogeBad() error {
-	err := validateHoge() // want "missing errors.Is check for functiontype.ErrHogeNotFound" "missing errors.Is check for functiontype.ErrHogeInvalid"
-	return fmt.Errorf("not wrapped: %v", err)
+	err := validateHoge()
+	return fmt.Errorf("not wrapped: %v", err) // want "functiontype.ErrHogeNotFound is flattened by %v" "functiontype.ErrHogeInvalid is flattened by %v"
 }
 
 func TestFmtErrorfWithVFooBad() error {
-	err := validateFoo() // want "missing errors.Is check for functiontype.ErrFooNotFound" "missing errors.Is check for functiontype.ErrFooInvalid"
-	return fmt.Errorf("not wrapped: %v", err)
+	err := validateFoo()
+	return fmt.Errorf("not wrapped: %v", err) // want "functiontype.ErrFooNotFound is flattened by %v" "functiontype.ErrFooInvalid is flattened by %v"
 }
 
 // =============================================================================
@@ -0,0 +1,46 @@
+// Package errors is a minimal stub of github.com/cockroachdb/errors for analyzer tests.
+package errors
+
+import (
+	stderrors "errors"
+
+	"github.com/cockroachdb/errors/markers"
+)
+
+func New(msg string) error {
+	return stderrors.New(msg)
+}
+
+func Newf(format string, args ...interface{}) error {
+	return stderrors.New(format)
+}
+
+func Wrapf(err error, format string, args ...interface{}) error {
+	return stderrors.Join(stderrors.New(format), err)
+}
+
+func WithHint(err error, hint string) error {
+	return stderrors.Join(err)
+}
+
+func Cause(err error) error {
+	for {
+		u := stderrors.Unwrap(err)
+		if u == nil {
+			return err
+		}
+		err = u
+	}
+}
+
+func Is(err, reference error) bool {
+	return markers.Is(err, reference)
+}
+
+func IsAny(err error, references ...error) bool {
+	return markers.IsAny(err, references...)
+}
+
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
@@ -0,0 +1,17 @@
+// Package markers is a minimal stub of github.com/cockroachdb/errors/markers for analyzer tests.
+package markers
+
+import stderrors "errors"
+
+func Is(err, reference error) bool {
+	return stderrors.Is(err, reference)
+}
+
+func IsAny(err error, references ...error) bool {
+	for _, ref := range references {
+		if stderrors.Is(err, ref) {
+			return true
+		}
+	}
+	return false
+}
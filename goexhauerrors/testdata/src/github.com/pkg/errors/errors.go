@@ -0,0 +1,42 @@
+// Package errors is a minimal stub of github.com/pkg/errors for analyzer tests.
+package errors
+
+import stderrors "errors"
+
+func New(message string) error {
+	return stderrors.New(message)
+}
+
+func Errorf(format string, args ...interface{}) error {
+	return stderrors.New(format)
+}
+
+func Wrap(err error, message string) error {
+	return stderrors.Join(stderrors.New(message), err)
+}
+
+func Wrapf(err error, format string, args ...interface{}) error {
+	return stderrors.Join(stderrors.New(format), err)
+}
+
+func WithStack(err error) error {
+	return stderrors.Join(err)
+}
+
+func Cause(err error) error {
+	for {
+		u := stderrors.Unwrap(err)
+		if u == nil {
+			return err
+		}
+		err = u
+	}
+}
+
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
@@ -0,0 +1,20 @@
+// Package xerrors is a minimal stub of golang.org/x/xerrors for analyzer tests.
+package xerrors
+
+import stderrors "errors"
+
+func New(text string) error {
+	return stderrors.New(text)
+}
+
+func Errorf(format string, a ...interface{}) error {
+	return stderrors.New(format)
+}
+
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
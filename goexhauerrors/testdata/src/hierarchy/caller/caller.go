@@ -0,0 +1,42 @@
+package caller
+
+import (
+	"errors"
+	"fmt"
+
+	"hierarchy"
+)
+
+var ErrProfileNotFound = fmt.Errorf("profile: %w", hierarchy.ErrUserNotFound) // want ErrProfileNotFound:`hierarchy/caller.ErrProfileNotFound` ErrProfileNotFound:`wraps:\[hierarchy.ErrUserNotFound,hierarchy.ErrNotFound\]`
+
+func FindProfile() error { // want FindProfile:`\[hierarchy/caller.ErrProfileNotFound\]`
+	return ErrProfileNotFound
+}
+
+func TestCrossPackageParent() {
+	err := hierarchy.Find("user")
+	if errors.Is(err, hierarchy.ErrNotFound) {
+		println("not found")
+	}
+}
+
+func TestCrossPackageChild() {
+	err := hierarchy.Find("user") // want "missing errors.Is check for hierarchy.ErrUserNotFound"
+	if errors.Is(err, hierarchy.ErrOrderNotFound) {
+		println("order not found")
+	}
+}
+
+func TestLocalChildOfImportedParent() {
+	err := FindProfile()
+	if errors.Is(err, hierarchy.ErrNotFound) {
+		println("not found")
+	}
+}
+
+func TestLocalChildUnchecked() {
+	err := FindProfile() // want "missing errors.Is check for hierarchy/caller.ErrProfileNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
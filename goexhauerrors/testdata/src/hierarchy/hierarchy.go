@@ -0,0 +1,94 @@
+package hierarchy
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`hierarchy.ErrNotFound`
+
+var ErrUserNotFound = fmt.Errorf("user: %w", ErrNotFound) // want ErrUserNotFound:`hierarchy.ErrUserNotFound` ErrUserNotFound:`wraps:\[hierarchy.ErrNotFound\]`
+
+var ErrOrderNotFound = fmt.Errorf("order: %w", ErrNotFound) // want ErrOrderNotFound:`hierarchy.ErrOrderNotFound` ErrOrderNotFound:`wraps:\[hierarchy.ErrNotFound\]`
+
+// ErrAdminNotFound is declared before its parent is resolved
+var ErrAdminNotFound = fmt.Errorf("admin: %w", ErrUserNotFound) // want ErrAdminNotFound:`hierarchy.ErrAdminNotFound` ErrAdminNotFound:`wraps:\[hierarchy.ErrUserNotFound,hierarchy.ErrNotFound\]`
+
+func FindUser(admin bool) error { // want FindUser:`\[hierarchy.ErrAdminNotFound, hierarchy.ErrUserNotFound\]`
+	if admin {
+		return ErrAdminNotFound
+	}
+	return ErrUserNotFound
+}
+
+func Find(kind string) error { // want Find:`\[hierarchy.ErrUserNotFound, hierarchy.ErrOrderNotFound\]`
+	if kind == "user" {
+		return ErrUserNotFound
+	}
+	return ErrOrderNotFound
+}
+
+func Lookup(kind string) error { // want Lookup:`\[hierarchy.ErrNotFound, hierarchy.ErrUserNotFound\]`
+	if kind == "" {
+		return ErrNotFound
+	}
+	return ErrUserNotFound
+}
+
+// =============================================================================
+// Test 1: Checking the parent covers every child
+// =============================================================================
+
+func TestParentCoversChildren() {
+	err := Find("user")
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+// =============================================================================
+// Test 2: Checking the parent covers deeper descendants
+// =============================================================================
+
+func TestParentCoversDescendants() {
+	err := FindUser(true)
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+func TestIntermediateCoversDescendants() {
+	err := FindUser(true)
+	if errors.Is(err, ErrUserNotFound) {
+		println("user not found")
+	}
+}
+
+// =============================================================================
+// Test 3: Checking one child leaves the other children outstanding
+// =============================================================================
+
+func TestChildLeavesSiblings() {
+	err := Find("user") // want "missing errors.Is check for hierarchy.ErrOrderNotFound"
+	if errors.Is(err, ErrUserNotFound) {
+		println("user not found")
+	}
+}
+
+// =============================================================================
+// Test 4: Checking a child does not cover the parent itself
+// =============================================================================
+
+func TestChildDoesNotCoverParent() {
+	err := Lookup("") // want "missing errors.Is check for hierarchy.ErrNotFound"
+	if errors.Is(err, ErrUserNotFound) {
+		println("user not found")
+	}
+}
+
+func TestChildUnchecked() {
+	err := FindUser(false) // want "missing errors.Is check for hierarchy.ErrAdminNotFound" "missing errors.Is check for hierarchy.ErrUserNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
@@ -0,0 +1,24 @@
+package caller
+
+import (
+	"errors"
+
+	"initsentinel"
+)
+
+func Checked() {
+	err := initsentinel.Validate("id")
+	if errors.Is(err, initsentinel.ErrInvalid) {
+		return
+	}
+	if errors.Is(err, initsentinel.ErrInternal) {
+		return
+	}
+}
+
+func Unchecked() {
+	err := initsentinel.Validate("id") // want "missing errors.Is check for initsentinel.ErrInternal"
+	if errors.Is(err, initsentinel.ErrInvalid) {
+		return
+	}
+}
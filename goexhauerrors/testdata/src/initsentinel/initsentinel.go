@@ -0,0 +1,132 @@
+package initsentinel
+
+import (
+	"errors"
+	"fmt"
+)
+
+const prefix = "initsentinel: "
+
+// Assigned in init
+var ErrNotFound error // want ErrNotFound:`initsentinel.ErrNotFound`
+var ErrConflict error // want ErrConflict:`initsentinel.ErrConflict`
+
+func init() {
+	ErrNotFound = errors.New(prefix + "not found")
+	ErrConflict = fmt.Errorf("%sconflict", prefix)
+}
+
+// Assigned in init, either way
+var ErrTimeout error // want ErrTimeout:`initsentinel.ErrTimeout`
+
+func init() {
+	if prefix == "" {
+		ErrTimeout = errors.New("timeout")
+	} else {
+		ErrTimeout = newErr("timeout")
+	}
+}
+
+// Initialized by helpers of this package
+func newErr(msg string) error {
+	return errors.New(prefix + msg)
+}
+
+func newCodeErr(code int) error { // want newCodeErr:`\[initsentinel.CodeError\]`
+	if code == 0 {
+		return newErr("unknown")
+	}
+	return &CodeError{Code: code}
+}
+
+type CodeError struct { // want CodeError:`initsentinel.CodeError`
+	Code int
+}
+
+func (e *CodeError) Error() string { return "code error" }
+
+var ErrInvalid = newErr("invalid")   // want ErrInvalid:`initsentinel.ErrInvalid`
+var ErrInternal = newCodeErr(500)    // want ErrInternal:`initsentinel.ErrInternal`
+var ErrAlias = identity(ErrNotFound) // not fresh: the helper returns its argument
+
+func identity(err error) error { // want identity:`\[0\]`
+	return err
+}
+
+// ErrWrapping wraps another error in init, which is not a fresh error
+var ErrWrapping error
+
+func init() {
+	ErrWrapping = fmt.Errorf("wrapping: %w", errors.ErrUnsupported)
+}
+
+// errPointer has its address taken
+var errPointer error
+
+func init() {
+	errPointer = errors.New("pointer")
+	set(&errPointer)
+}
+
+func set(p *error) {}
+
+// LastErr is also assigned at runtime, so it is not a sentinel
+var LastErr error
+
+func init() {
+	LastErr = errors.New("none")
+}
+
+func Fail(msg string) error {
+	LastErr = errors.New(msg)
+	return LastErr
+}
+
+// ErrClosure is assigned by a closure called in init
+var ErrClosure error // want ErrClosure:`initsentinel.ErrClosure`
+
+func init() {
+	func() {
+		ErrClosure = errors.New("closure")
+	}()
+}
+
+func Close() error { // want Close:`\[initsentinel.ErrClosure\]`
+	return ErrClosure
+}
+
+func Find(id string) error { // want Find:`\[initsentinel.ErrNotFound, initsentinel.ErrConflict, initsentinel.ErrTimeout\]`
+	switch id {
+	case "":
+		return ErrNotFound
+	case "dup":
+		return ErrConflict
+	}
+	return ErrTimeout
+}
+
+func Validate(id string) error { // want Validate:`\[initsentinel.ErrInvalid, initsentinel.ErrInternal\]`
+	if id == "" {
+		return ErrInvalid
+	}
+	if id == "?" {
+		return ErrAlias
+	}
+	if id == "%" {
+		return ErrWrapping
+	}
+	if id == "*" {
+		return errPointer
+	}
+	if id == "!" {
+		return LastErr
+	}
+	return ErrInternal
+}
+
+func FindUnchecked() {
+	err := Find("id") // want "missing errors.Is check for initsentinel.ErrTimeout"
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict) {
+		return
+	}
+}
@@ -89,13 +89,60 @@ func TestInterfaceCallGood(p ErrorProducer) {
 
 func TestInterfaceVariable() {
 	var p ErrorProducer = &ImplA{}
-	// Interface variable call - should warn for all implementations' errors
-	err := p.Produce() // want "missing errors.Is check for interfacecall.ErrOne" "missing errors.Is check for interfacecall.ErrTwo" "missing errors.Is check for interfacecall.CustomError"
+	// Receiver is known to be *ImplA - should only warn for ImplA's errors
+	err := p.Produce() // want "missing errors.Is check for interfacecall.ErrOne"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func TestInterfaceVariableGood() {
+	var p ErrorProducer = &ImplA{}
+	err := p.Produce()
+	if errors.Is(err, ErrOne) {
+		println("error one")
+	}
+}
+
+func TestInterfaceVariableBranches(useB bool) {
+	var p ErrorProducer = &ImplA{}
+	if useB {
+		p = &ImplB{}
+	}
+	// Receiver is *ImplA or *ImplB - should warn for both, but not for ImplC
+	err := p.Produce() // want "missing errors.Is check for interfacecall.ErrOne" "missing errors.Is check for interfacecall.ErrTwo"
 	if err != nil {
 		println(err.Error())
 	}
 }
 
+func newImplB() *ImplB {
+	return &ImplB{}
+}
+
+func newProducer() ErrorProducer {
+	return &ImplC{}
+}
+
+func TestInterfaceFromConstructor() {
+	var p ErrorProducer = newImplB()
+	err := p.Produce() // want "missing errors.Is check for interfacecall.ErrTwo"
+	if err != nil {
+		println(err.Error())
+	}
+	q := newProducer()
+	err = q.Produce() // want "missing errors.Is check for interfacecall.CustomError"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// produceWith propagates only the errors of the concrete producer
+func produceWith() error { // want produceWith:`\[interfacecall.ErrTwo\]`
+	var p ErrorProducer = &ImplB{}
+	return p.Produce()
+}
+
 // =============================================================================
 // Test: Concrete type is still detected normally
 // =============================================================================
@@ -144,3 +191,64 @@ func TestSingleImplGood(s SingleProducer) {
 		println("error three")
 	}
 }
+
+// =============================================================================
+// Test: Receiver type that only forwards to an embedded interface
+// =============================================================================
+
+type Repository interface {
+	Find() error // want Find:`\[interfacecall.ErrOne\]`
+}
+
+type RepoImpl struct{}
+
+func (r RepoImpl) Find() error { // want Find:`\[interfacecall.ErrOne\]`
+	return ErrOne
+}
+
+// Service forwards Find to whatever Repository it embeds
+type Service struct {
+	Repository
+}
+
+func TestEmbeddedInterfaceReceiver() {
+	var r Repository = &Service{Repository: RepoImpl{}}
+	// Service has no Find of its own - fall back to the implementations of Repository
+	err := r.Find() // want "missing errors.Is check for interfacecall.ErrOne"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// findWithService propagates the errors of the implementations Service can forward to
+func findWithService() error { // want findWithService:`\[interfacecall.ErrOne\]`
+	var r Repository = &Service{Repository: RepoImpl{}}
+	return r.Find()
+}
+
+// =============================================================================
+// Test: Receiver holding an instantiated generic implementation
+// =============================================================================
+
+type Loader interface {
+	Load() error // want Load:`\[interfacecall.ErrTwo\]`
+}
+
+type GenRepo[T any] struct{}
+
+func (r *GenRepo[T]) Load() error { // want Load:`\[interfacecall.ErrTwo\]`
+	return ErrTwo
+}
+
+func TestGenericImplementation() {
+	var r Loader = &GenRepo[int]{}
+	// The facts of (*GenRepo[int]).Load are those of its generic origin
+	err := r.Load() // want "missing errors.Is check for interfacecall.ErrTwo"
+	_ = err
+}
+
+// loadWithGeneric propagates the errors of the generic implementation
+func loadWithGeneric() error { // want loadWithGeneric:`\[interfacecall.ErrTwo\]`
+	var r Loader = &GenRepo[int]{}
+	return r.Load()
+}
@@ -0,0 +1,36 @@
+package caller
+
+import (
+	"errors"
+	"iter"
+
+	"iterator"
+)
+
+type UserSource interface {
+	All() iter.Seq2[iterator.User, error]
+}
+
+func TestCrossPackage(r *iterator.Repo) {
+	for _, err := range r.All() { // want "missing errors.Is check for iterator.ErrQuery" "missing errors.Is check for iterator.ErrDecode"
+		if errors.Is(err, iterator.ErrClosed) {
+			return
+		}
+	}
+}
+
+func TestInterface(src UserSource) {
+	for _, err := range src.All() { // want "missing errors.Is check for iterator.ErrClosed" "missing errors.Is check for iterator.ErrQuery" "missing errors.Is check for iterator.ErrDecode"
+		if err != nil {
+			println(err.Error())
+		}
+	}
+}
+
+func TestInterfaceChecked(src UserSource) {
+	for _, err := range src.All() {
+		if errors.Is(err, iterator.ErrClosed) || errors.Is(err, iterator.ErrQuery) || errors.Is(err, iterator.ErrDecode) {
+			return
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package iterator
+
+import (
+	"errors"
+	"iter"
+)
+
+var ErrClosed = errors.New("closed") // want ErrClosed:`iterator.ErrClosed`
+var ErrDecode = errors.New("decode") // want ErrDecode:`iterator.ErrDecode`
+var ErrQuery = errors.New("query")   // want ErrQuery:`iterator.ErrQuery`
+
+type User struct {
+	Name string
+}
+
+type Repo struct {
+	closed bool
+}
+
+func (r *Repo) query() error { // want query:`\[iterator.ErrQuery\]`
+	if r.closed {
+		return ErrQuery
+	}
+	return nil
+}
+
+// All yields the users of the repository, or the error that stopped the iteration
+func (r *Repo) All() iter.Seq2[User, error] { // want All:`yields:\[iterator.ErrClosed, iterator.ErrDecode, iterator.ErrQuery\]`
+	return func(yield func(User, error) bool) {
+		if r.closed {
+			yield(User{}, ErrClosed)
+			return
+		}
+		if err := r.query(); err != nil {
+			yield(User{}, err)
+			return
+		}
+		if !yield(User{Name: "a"}, nil) {
+			return
+		}
+		yield(User{}, ErrDecode)
+	}
+}
+
+// Active returns the iterator of All
+func (r *Repo) Active() iter.Seq2[User, error] { // want Active:`yields:\[iterator.ErrClosed, iterator.ErrDecode, iterator.ErrQuery\]`
+	return r.All()
+}
+
+// Rows is an iterator itself
+func (r *Repo) Rows(yield func(int, error) bool) { // want Rows:`yields:\[iterator.ErrDecode\]`
+	yield(0, ErrDecode)
+}
+
+// Names does not yield errors
+func (r *Repo) Names() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		yield("a")
+	}
+}
+
+// =============================================================================
+// Test 1: Unchecked errors yielded by an iterator
+// =============================================================================
+
+func TestRangeUnchecked(r *Repo) {
+	for u, err := range r.All() { // want "missing errors.Is check for iterator.ErrClosed" "missing errors.Is check for iterator.ErrQuery" "missing errors.Is check for iterator.ErrDecode"
+		if err != nil {
+			println(err.Error())
+			continue
+		}
+		println(u.Name)
+	}
+}
+
+func TestRangeChecked(r *Repo) {
+	for u, err := range r.All() {
+		if errors.Is(err, ErrClosed) || errors.Is(err, ErrQuery) || errors.Is(err, ErrDecode) {
+			break
+		}
+		println(u.Name)
+	}
+}
+
+func TestRangePartiallyChecked(r *Repo) {
+	for u, err := range r.All() { // want "missing errors.Is check for iterator.ErrQuery" "missing errors.Is check for iterator.ErrDecode"
+		if errors.Is(err, ErrClosed) {
+			break
+		}
+		println(u.Name)
+	}
+}
+
+// =============================================================================
+// Test 2: Propagating the yielded error
+// =============================================================================
+
+func TestRangePropagated(r *Repo) error { // want TestRangePropagated:`\[iterator.ErrClosed, iterator.ErrDecode, iterator.ErrQuery\]`
+	for u, err := range r.All() {
+		if err != nil {
+			return err
+		}
+		println(u.Name)
+	}
+	return nil
+}
+
+// =============================================================================
+// Test 3: Iterators returning other iterators and iterator methods
+// =============================================================================
+
+func TestRangeActive(r *Repo) {
+	for _, err := range r.Active() { // want "missing errors.Is check for iterator.ErrClosed" "missing errors.Is check for iterator.ErrQuery" "missing errors.Is check for iterator.ErrDecode"
+		if err != nil {
+			println(err.Error())
+		}
+	}
+}
+
+func TestRangeMethod(r *Repo) {
+	for _, err := range r.Rows { // want "missing errors.Is check for iterator.ErrDecode"
+		println(err)
+	}
+}
+
+func TestRangeMethodChecked(r *Repo) {
+	for _, err := range r.Rows {
+		if errors.Is(err, ErrDecode) {
+			println("decode")
+		}
+	}
+}
+
+func TestRangeNames(r *Repo) {
+	for name := range r.Names() {
+		println(name)
+	}
+}
@@ -0,0 +1,22 @@
+package caller
+
+import (
+	"errors"
+
+	"mockimpl"
+	_ "mockimpl/mocks" // import for implementation discovery
+)
+
+func Find(repo mockimpl.Repository) {
+	err := repo.Find("1") // want "missing errors.Is check for mockimpl.ErrNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func FindGood(repo mockimpl.Repository) {
+	err := repo.Find("1")
+	if errors.Is(err, mockimpl.ErrNotFound) {
+		println("not found")
+	}
+}
@@ -0,0 +1,23 @@
+package mockimpl
+
+import "errors"
+
+var ErrFake = errors.New("fake") // want ErrFake:`mockimpl.ErrFake`
+
+// FakeRepo is a hand-written fake.
+//
+//goexhauerrors:mock
+type FakeRepo struct{} // want FakeRepo:`mock`
+
+func (f *FakeRepo) Find(id string) error { // want Find:`\[mockimpl.ErrFake\]`
+	return ErrFake
+}
+
+func UseFake() {
+	// Calling a mock through its concrete type still reports its own errors
+	var repo Repository = &FakeRepo{}
+	err := repo.Find("1") // want "missing errors.Is check for mockimpl.ErrFake"
+	if err != nil {
+		println(err.Error())
+	}
+}
@@ -0,0 +1,13 @@
+package mockimpl
+
+import "errors"
+
+// NOTE: this file matches -mockFiles=mock_*.go
+
+var ErrMock = errors.New("mock") // want ErrMock:`mockimpl.ErrMock`
+
+type MockRepo struct{}
+
+func (m *MockRepo) Find(id string) error { // want Find:`\[mockimpl.ErrMock\]`
+	return ErrMock
+}
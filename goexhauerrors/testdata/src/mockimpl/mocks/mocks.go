@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"errors"
+
+	"mockimpl"
+)
+
+// NOTE: this package matches -mockPackages=mockimpl/mocks, so GeneratedRepo
+// gets no ImplementsFact.
+
+var ErrGenerated = errors.New("generated") // want ErrGenerated:`mockimpl/mocks.ErrGenerated`
+
+type GeneratedRepo struct{}
+
+func (g *GeneratedRepo) Find(id string) error { // want Find:`\[mockimpl/mocks.ErrGenerated\]`
+	return ErrGenerated
+}
+
+var _ mockimpl.Repository = (*GeneratedRepo)(nil)
@@ -0,0 +1,31 @@
+package mockimpl
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`mockimpl.ErrNotFound`
+
+// Repository is implemented by UserRepo in production and by mocks in tests.
+// Only UserRepo contributes to its error set.
+type Repository interface {
+	Find(id string) error // want Find:`\[mockimpl.ErrNotFound\]`
+}
+
+type UserRepo struct{}
+
+func (r *UserRepo) Find(id string) error { // want Find:`\[mockimpl.ErrNotFound\]`
+	return ErrNotFound
+}
+
+func UseRepository(repo Repository) {
+	err := repo.Find("1") // want "missing errors.Is check for mockimpl.ErrNotFound"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func UseRepositoryGood(repo Repository) {
+	err := repo.Find("1")
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
@@ -0,0 +1,127 @@
+package namedresult
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`namedresult.ErrNotFound`
+var ErrConflict = errors.New("conflict")  // want ErrConflict:`namedresult.ErrConflict`
+var ErrSave = errors.New("save")          // want ErrSave:`namedresult.ErrSave`
+var ErrPanic = errors.New("panic")        // want ErrPanic:`namedresult.ErrPanic`
+
+func put(id string) error { // want put:`\[namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	if id == "" {
+		return ErrNotFound
+	}
+	return ErrConflict
+}
+
+// =============================================================================
+// Fact computation
+// =============================================================================
+
+// SaveBare assigns the named result and returns it with a bare return
+func SaveBare(id string) (err error) { // want SaveBare:`\[namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	err = put(id)
+	return
+}
+
+// SaveWrapped wraps the named result in a deferred closure
+func SaveWrapped(id string) (err error) { // want SaveWrapped:`\[namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("save: %w", err)
+		}
+	}()
+	err = put(id)
+	return
+}
+
+// SaveReplaced replaces every error with ErrSave in a deferred closure
+func SaveReplaced(id string) (err error) { // want SaveReplaced:`\[namedresult.ErrSave\]`
+	defer func() {
+		if err != nil {
+			err = ErrSave
+		}
+	}()
+	return put(id)
+}
+
+// SaveJoined keeps the errors of put when joining them with another error
+func SaveJoined(id string) (err error) { // want SaveJoined:`\[namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, ErrSave)
+		}
+	}()
+	return put(id)
+}
+
+// SaveRecovered sets the named result when recovering from a panic
+func SaveRecovered(id string) (err error) { // want SaveRecovered:`\[namedresult.ErrPanic, namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	defer func() {
+		if r := recover(); r != nil {
+			err = ErrPanic
+		}
+	}()
+	err = put(id)
+	return err
+}
+
+// =============================================================================
+// Propagation check
+// =============================================================================
+
+// PropagateBare propagates the errors of put with a bare return
+func PropagateBare(id string) (err error) { // want PropagateBare:`\[namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	err = put(id)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+// PropagateIfInit propagates the errors of put assigned in an if statement
+func PropagateIfInit(id string) (err error) { // want PropagateIfInit:`\[namedresult.ErrNotFound, namedresult.ErrConflict\]`
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("save: %w", err)
+		}
+	}()
+	if err = put(id); err != nil {
+		return
+	}
+	return nil
+}
+
+// DropBare discards the errors of put assigned to another variable
+func DropBare(id string) (err error) {
+	e := put(id) // want "missing errors.Is check for namedresult.ErrConflict" "missing errors.Is check for namedresult.ErrNotFound"
+	if e != nil {
+		return
+	}
+	return nil
+}
+
+func CallSaveReplaced() {
+	err := SaveReplaced("id") // want "missing errors.Is check for namedresult.ErrSave"
+	fmt.Println(err)
+}
+
+func CallSaveReplacedGood() {
+	err := SaveReplaced("id")
+	if errors.Is(err, ErrSave) {
+		fmt.Println(err)
+	}
+}
+
+func CallSaveWrapped() {
+	err := SaveWrapped("id")
+	if err == ErrNotFound { // want "namedresult.ErrNotFound may be returned wrapped, which == does not match; use errors.Is"
+		return
+	}
+	if errors.Is(err, ErrConflict) {
+		return
+	}
+}
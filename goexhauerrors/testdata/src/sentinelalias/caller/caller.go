@@ -0,0 +1,39 @@
+package caller
+
+import (
+	"errors"
+
+	"sentinelalias/facade"
+)
+
+// Checked checks every error through the facade
+func Checked() {
+	err := facade.Get("id")
+	if errors.Is(err, facade.ErrNotFound) {
+		return
+	}
+	if errors.Is(err, facade.ErrDuplicate) {
+		return
+	}
+}
+
+// Unchecked does not import storage, so diagnostics name the facade aliases
+func Unchecked() {
+	err := facade.Get("id") // want "missing errors.Is check for sentinelalias/facade.ErrDuplicate"
+	if errors.Is(err, facade.ErrMissing) {
+		return
+	}
+}
+
+// CheckedParent covers ErrUserNotFound by checking the error it wraps
+func CheckedParent() {
+	err := facade.GetUser("id")
+	if errors.Is(err, facade.ErrNotFound) {
+		return
+	}
+}
+
+func UncheckedUser() {
+	err := facade.GetUser("id") // want "missing errors.Is check for sentinelalias/facade.ErrUserNotFound"
+	println(err)
+}
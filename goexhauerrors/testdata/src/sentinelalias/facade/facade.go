@@ -0,0 +1,69 @@
+package facade
+
+import (
+	"errors"
+
+	"sentinelalias/storage"
+)
+
+// Re-exported errors of the storage package
+var (
+	ErrNotFound     = storage.ErrNotFound     // want ErrNotFound:`sentinelalias/storage.ErrNotFound`
+	ErrDuplicate    = storage.ErrConflict     // want ErrDuplicate:`sentinelalias/storage.ErrConflict`
+	ErrUserNotFound = storage.ErrUserNotFound // want ErrUserNotFound:`sentinelalias/storage.ErrUserNotFound` ErrUserNotFound:`wraps:\[sentinelalias/storage.ErrNotFound\]`
+)
+
+// ErrMissing aliases another alias
+var ErrMissing = ErrNotFound // want ErrMissing:`sentinelalias/storage.ErrNotFound`
+
+// errGone is an unexported alias of a sentinel of this package
+var errGone = ErrClosed // want errGone:`sentinelalias/facade.ErrClosed`
+
+var ErrClosed = errors.New("closed") // want ErrClosed:`sentinelalias/facade.ErrClosed`
+
+// Get forwards to the storage package
+func Get(id string) error { // want Get:`\[sentinelalias/storage.ErrNotFound, sentinelalias/storage.ErrConflict\]`
+	return storage.Get(id)
+}
+
+// Find returns the aliases themselves
+func Find(id string) error { // want Find:`\[sentinelalias/storage.ErrNotFound, sentinelalias/facade.ErrClosed\]`
+	if id == "" {
+		return ErrMissing
+	}
+	return errGone
+}
+
+func GetUser(id string) error { // want GetUser:`\[sentinelalias/storage.ErrUserNotFound\]`
+	return storage.GetUser(id)
+}
+
+// CheckedThroughAlias checks the errors of storage through the aliases
+func CheckedThroughAlias() {
+	err := storage.Get("id")
+	if errors.Is(err, ErrNotFound) {
+		return
+	}
+	if errors.Is(err, ErrDuplicate) {
+		return
+	}
+}
+
+// CheckedLocalAlias checks ErrClosed through its unexported alias
+func CheckedLocalAlias() {
+	err := Find("id")
+	if errors.Is(err, ErrMissing) {
+		return
+	}
+	if err == errGone {
+		return
+	}
+}
+
+// UncheckedStorage imports storage, so diagnostics name the storage sentinels
+func UncheckedStorage() {
+	err := storage.Get("id") // want "missing errors.Is check for sentinelalias/storage.ErrConflict"
+	if errors.Is(err, ErrMissing) {
+		return
+	}
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`sentinelalias/storage.ErrNotFound`
+var ErrConflict = errors.New("conflict")  // want ErrConflict:`sentinelalias/storage.ErrConflict`
+
+var ErrUserNotFound = fmt.Errorf("user: %w", ErrNotFound) // want ErrUserNotFound:`sentinelalias/storage.ErrUserNotFound` ErrUserNotFound:`wraps:\[sentinelalias/storage.ErrNotFound\]`
+
+func Get(id string) error { // want Get:`\[sentinelalias/storage.ErrNotFound, sentinelalias/storage.ErrConflict\]`
+	if id == "" {
+		return ErrNotFound
+	}
+	return ErrConflict
+}
+
+func GetUser(id string) error { // want GetUser:`\[sentinelalias/storage.ErrUserNotFound\]`
+	return ErrUserNotFound
+}
@@ -0,0 +1,37 @@
+package caller
+
+import (
+	"errors"
+
+	"typeparam"
+)
+
+func TestCrossPackageUser() {
+	err := typeparam.Fetch(typeparam.UserRepo{}, 1) // want "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
+
+func TestCrossPackageExplicit() {
+	err := typeparam.Fetch[typeparam.UserRepo](typeparam.UserRepo{}, 1) // want "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
+
+func TestCrossPackageExplicitVia() error { // want TestCrossPackageExplicitVia:`\[typeparam.ErrConflict\]`
+	return typeparam.FetchVia[*typeparam.OrderRepo](&typeparam.OrderRepo{})
+}
+
+func TestCrossPackageVia() error { // want TestCrossPackageVia:`\[typeparam.ErrConflict\]`
+	return typeparam.FetchVia(&typeparam.OrderRepo{})
+}
+
+func TestCrossPackageChecked() {
+	err := typeparam.Fetch(&typeparam.OrderRepo{}, 1)
+	if errors.Is(err, typeparam.ErrConflict) {
+		println("conflict")
+	}
+}
+
+func LoadCrossPackage[R typeparam.Repository](r R) {
+	err := r.Get(1) // want "missing errors.Is check for typeparam.ErrConflict" "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
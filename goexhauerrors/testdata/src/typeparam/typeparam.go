@@ -0,0 +1,98 @@
+package typeparam
+
+import "errors"
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`typeparam.ErrNotFound`
+var ErrConflict = errors.New("conflict")  // want ErrConflict:`typeparam.ErrConflict`
+var ErrTimeout = errors.New("timeout")    // want ErrTimeout:`typeparam.ErrTimeout`
+
+type Repository interface {
+	Get(id int) error // want Get:`\[typeparam.ErrConflict, typeparam.ErrNotFound\]`
+}
+
+type UserRepo struct{}
+
+func (UserRepo) Get(id int) error { // want Get:`\[typeparam.ErrNotFound\]`
+	return ErrNotFound
+}
+
+type OrderRepo struct{}
+
+func (*OrderRepo) Get(id int) error { // want Get:`\[typeparam.ErrConflict\]`
+	return ErrConflict
+}
+
+type Waiter struct{}
+
+func (Waiter) Wait() error { // want Wait:`\[typeparam.ErrTimeout\]`
+	return ErrTimeout
+}
+
+// =============================================================================
+// Test 1: Method calls on a type parameter use the constraint's implementations
+// =============================================================================
+
+func LoadUnchecked[R Repository](r R, id int) {
+	err := r.Get(id) // want "missing errors.Is check for typeparam.ErrConflict" "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
+
+func LoadChecked[R Repository](r R, id int) {
+	err := r.Get(id)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict) {
+		println("handled")
+	}
+}
+
+// Inline constraints are resolved to their implementations too
+func WaitUnchecked[W interface{ Wait() error }](w W) {
+	err := w.Wait() // want "missing errors.Is check for typeparam.ErrTimeout"
+	println(err)
+}
+
+// =============================================================================
+// Test 2: Instantiation sites narrow to the type argument's method
+// =============================================================================
+
+func Fetch[R Repository](r R, id int) error { // want Fetch:`\[call:0.Get\]`
+	return r.Get(id)
+}
+
+// FetchVia passes its type parameter on to Fetch
+func FetchVia[R Repository](r R) error { // want FetchVia:`\[call:0.Get\]`
+	return Fetch(r, 1)
+}
+
+func TestFetchUser() {
+	err := Fetch(UserRepo{}, 1) // want "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
+
+func TestFetchOrder() {
+	err := Fetch(&OrderRepo{}, 1) // want "missing errors.Is check for typeparam.ErrConflict"
+	println(err)
+}
+
+func TestFetchUserChecked() {
+	err := Fetch(UserRepo{}, 1)
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+func TestFetchViaOrder() {
+	err := FetchVia(&OrderRepo{}) // want "missing errors.Is check for typeparam.ErrConflict"
+	println(err)
+}
+
+// An interface type argument yields the errors of all implementations
+func TestFetchInterface(r Repository) {
+	err := Fetch(r, 1) // want "missing errors.Is check for typeparam.ErrConflict" "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
+
+// Inside a generic function the type argument is not known yet
+func FetchInside[R Repository](r R) {
+	err := Fetch(r, 1) // want "missing errors.Is check for typeparam.ErrConflict" "missing errors.Is check for typeparam.ErrNotFound"
+	println(err)
+}
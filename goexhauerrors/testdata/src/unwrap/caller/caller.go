@@ -0,0 +1,96 @@
+package caller
+
+import (
+	"errors"
+
+	"unwrap/ops"
+)
+
+// Checking the inner sentinel covers the wrapper as well.
+func InnerIsGood() {
+	err := ops.Read()
+	if errors.Is(err, ops.ErrTimeout) {
+		println("timeout")
+	}
+}
+
+// Checking the wrapper with errors.As covers the inner sentinel as well.
+func WrapperAsGood() {
+	err := ops.Read()
+	var opErr *ops.OpError
+	if errors.As(err, &opErr) {
+		println(opErr.Op)
+	}
+}
+
+func UncheckedBad() {
+	err := ops.Read() // want "missing errors.Is check for unwrap/ops.OpError" "missing errors.Is check for unwrap/ops.ErrTimeout"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func ConstructorInnerGood() {
+	err := ops.Write()
+	if errors.Is(err, ops.ErrClosed) {
+		println("closed")
+	}
+}
+
+// Errors passed to the constructor at the call site are carried by the wrapper.
+func ConstructorCallSiteGood() {
+	err := ops.NewOpError("dial", ops.ErrTimeout)
+	if errors.Is(err, ops.ErrTimeout) {
+		println("timeout")
+	}
+}
+
+func ConstructorCallSiteBad() {
+	err := ops.NewOpError("dial", ops.ErrTimeout) // want "missing errors.Is check for unwrap/ops.OpError" "missing errors.Is check for unwrap/ops.ErrTimeout"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func VariableGood() {
+	err := ops.Flush()
+	var opErr *ops.OpError
+	if errors.As(err, &opErr) {
+		println(opErr.Op)
+	}
+}
+
+// The wrapper is only covered once every error it carries is checked.
+func MultiPartialBad() {
+	err := ops.Close() // want "missing errors.Is check for unwrap/ops.JoinError" "missing errors.Is check for unwrap/ops.ErrClosed"
+	if errors.Is(err, ops.ErrTimeout) {
+		println("timeout")
+	}
+}
+
+func MultiGood() {
+	err := ops.Close()
+	if errors.Is(err, ops.ErrTimeout) || errors.Is(err, ops.ErrClosed) {
+		println("timeout or closed")
+	}
+}
+
+// ErrTimeout is also returned directly, so checking the wrapper does not cover it.
+func DirectAndWrappedBad() {
+	err := ops.Sync(true) // want "missing errors.Is check for unwrap/ops.ErrTimeout"
+	var opErr *ops.OpError
+	if errors.As(err, &opErr) {
+		println(opErr.Op)
+	}
+}
+
+// Since ErrTimeout is not only carried by the wrapper, the wrapper needs its own check.
+func DirectAndWrappedGood() {
+	err := ops.Sync(true)
+	var opErr *ops.OpError
+	if errors.As(err, &opErr) {
+		println(opErr.Op)
+	} else if errors.Is(err, ops.ErrTimeout) {
+		println("timeout")
+	}
+}
@@ -0,0 +1,59 @@
+package ops
+
+import "errors"
+
+var ErrTimeout = errors.New("timeout") // want ErrTimeout:`unwrap/ops.ErrTimeout`
+var ErrClosed = errors.New("closed")   // want ErrClosed:`unwrap/ops.ErrClosed`
+
+// OpError records the failed operation and the error that caused it.
+type OpError struct { // want OpError:`unwrap/ops.OpError` OpError:`unwraps:Err`
+	Op  string
+	Err error
+}
+
+func (e *OpError) Error() string { return e.Op + ": " + e.Err.Error() }
+
+func (e *OpError) Unwrap() error { return e.Err }
+
+// JoinError carries several errors at once.
+type JoinError struct { // want JoinError:`unwrap/ops.JoinError` JoinError:`unwraps:Errs`
+	Errs []error
+}
+
+func (e *JoinError) Error() string { return "joined" }
+
+func (e *JoinError) Unwrap() []error { return e.Errs }
+
+// Read returns the wrapper built directly with a composite literal.
+func Read() error { // want Read:`\[unwrap/ops.OpError, unwrap/ops.ErrTimeout\]`
+	return &OpError{Op: "read", Err: ErrTimeout}
+}
+
+// NewOpError is a constructor: the err parameter flows through the Err field.
+func NewOpError(op string, err error) error { // want NewOpError:`\[unwrap/ops.OpError\]` NewOpError:`\[wrapped:1\]`
+	return &OpError{Op: op, Err: err}
+}
+
+// Write builds the wrapper through the constructor.
+func Write() error { // want Write:`\[unwrap/ops.OpError, unwrap/ops.ErrClosed\]`
+	return NewOpError("write", ErrClosed)
+}
+
+// Flush returns the wrapper stored in a variable first.
+func Flush() error { // want Flush:`\[unwrap/ops.OpError, unwrap/ops.ErrTimeout\]`
+	err := &OpError{Op: "flush", Err: ErrTimeout}
+	return err
+}
+
+// Close wraps both sentinels via Unwrap() []error.
+func Close() error { // want Close:`\[unwrap/ops.JoinError, unwrap/ops.ErrTimeout, unwrap/ops.ErrClosed\]`
+	return &JoinError{Errs: []error{ErrTimeout, ErrClosed}}
+}
+
+// Sync returns ErrTimeout both directly and through the wrapper.
+func Sync(flag bool) error { // want Sync:`\[unwrap/ops.ErrTimeout, unwrap/ops.OpError\]`
+	if flag {
+		return ErrTimeout
+	}
+	return &OpError{Op: "sync", Err: ErrTimeout}
+}
@@ -0,0 +1,36 @@
+package caller
+
+import (
+	"errors"
+
+	"variadicflow"
+)
+
+var ErrLocal = errors.New("local") // want ErrLocal:`variadicflow/caller.ErrLocal`
+
+func TestCrossPackage() {
+	err := variadicflow.RunAll( // want "missing errors.Is check for variadicflow/caller.ErrLocal" "missing errors.Is check for variadicflow.ErrFirst"
+		func() error { return ErrLocal },
+		func() error { return variadicflow.ErrFirst },
+	)
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func TestCrossPackageGood() {
+	err := variadicflow.RunAll(
+		func() error { return ErrLocal },
+		func() error { return variadicflow.ErrFirst },
+	)
+	if errors.Is(err, ErrLocal) || errors.Is(err, variadicflow.ErrFirst) {
+		println("failed")
+	}
+}
+
+func TestCrossPackageOption() {
+	_, err := variadicflow.New(variadicflow.WithValidPort(-1)) // want "missing errors.Is check for variadicflow.ErrPort"
+	if err != nil {
+		println(err.Error())
+	}
+}
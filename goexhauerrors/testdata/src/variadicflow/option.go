@@ -0,0 +1,75 @@
+package variadicflow
+
+import "errors"
+
+// =============================================================================
+// Test 6: Functional options
+// =============================================================================
+
+type Config struct {
+	Port int
+}
+
+type Option func(*Config) error
+
+// New applies every option to a new Config
+func New(opts ...Option) (*Config, error) { // want New:`\[each:call:0\]`
+	cfg := &Config{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func WithPort(port int) Option {
+	return func(cfg *Config) error {
+		cfg.Port = port
+		return nil
+	}
+}
+
+// WithValidPort returns an option rejecting negative ports
+func WithValidPort(port int) Option { // want WithValidPort:`returnsFunc:\[variadicflow.ErrPort\]`
+	return func(cfg *Config) error {
+		if port < 0 {
+			return ErrPort
+		}
+		cfg.Port = port
+		return nil
+	}
+}
+
+func withStrictConfig(cfg *Config) error { // want withStrictConfig:`\[variadicflow.ErrConfig\]`
+	if cfg.Port == 0 {
+		return ErrConfig
+	}
+	return nil
+}
+
+func TestNewOptions() {
+	_, err := New(WithPort(80), withStrictConfig) // want "missing errors.Is check for variadicflow.ErrConfig"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func TestNewOptionConstructor() {
+	_, err := New(WithValidPort(-1)) // want "missing errors.Is check for variadicflow.ErrPort"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func TestNewOptionConstructorGood() {
+	_, err := New(WithValidPort(-1))
+	if errors.Is(err, ErrPort) {
+		println("invalid port")
+	}
+}
+
+// newWithValidPort propagates the errors of the option it passes to New
+func newWithValidPort() (*Config, error) { // want newWithValidPort:`\[variadicflow.ErrPort\]`
+	return New(WithValidPort(-1))
+}
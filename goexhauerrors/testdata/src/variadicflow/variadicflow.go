@@ -0,0 +1,149 @@
+package variadicflow
+
+import "errors"
+
+var ErrFirst = errors.New("first")   // want ErrFirst:`variadicflow.ErrFirst`
+var ErrSecond = errors.New("second") // want ErrSecond:`variadicflow.ErrSecond`
+var ErrConfig = errors.New("config") // want ErrConfig:`variadicflow.ErrConfig`
+var ErrPort = errors.New("port")     // want ErrPort:`variadicflow.ErrPort`
+
+// RunAll calls every function in fns and returns the first error
+func RunAll(fns ...func() error) error { // want RunAll:`\[each:call:0\]`
+	for _, fn := range fns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunEach takes the functions as a slice instead of a variadic parameter
+func RunEach(fns []func() error) error { // want RunEach:`\[each:call:0\]`
+	for i := range fns {
+		if err := fns[i](); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAllWrapper passes its variadic parameter on to RunAll
+func RunAllWrapper(fns ...func() error) error { // want RunAllWrapper:`\[each:call:0\]`
+	return RunAll(fns...)
+}
+
+func first() error { // want first:`\[variadicflow.ErrFirst\]`
+	return ErrFirst
+}
+
+type Job struct{}
+
+func (j *Job) Run() error { // want Run:`\[variadicflow.ErrSecond\]`
+	return ErrSecond
+}
+
+// =============================================================================
+// Test 1: Several function literals passed to a variadic parameter
+// =============================================================================
+
+func TestRunAllLiterals() {
+	err := RunAll( // want "missing errors.Is check for variadicflow.ErrFirst" "missing errors.Is check for variadicflow.ErrSecond"
+		func() error { return ErrFirst },
+		func() error { return ErrSecond },
+	)
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func TestRunAllLiteralsGood() {
+	err := RunAll(
+		func() error { return ErrFirst },
+		func() error { return ErrSecond },
+	)
+	if errors.Is(err, ErrFirst) || errors.Is(err, ErrSecond) {
+		println("failed")
+	}
+}
+
+// =============================================================================
+// Test 2: Function and method values passed to a variadic parameter
+// =============================================================================
+
+func TestRunAllFuncValues(j *Job) {
+	err := RunAll(first, j.Run) // want "missing errors.Is check for variadicflow.ErrFirst" "missing errors.Is check for variadicflow.ErrSecond"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Test 3: Slice literal spread into a variadic parameter
+// =============================================================================
+
+func TestRunAllSpread() {
+	err := RunAll([]func() error{first, func() error { return ErrSecond }}...) // want "missing errors.Is check for variadicflow.ErrFirst" "missing errors.Is check for variadicflow.ErrSecond"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Test 4: Slice-of-func parameter
+// =============================================================================
+
+func TestRunEach(j *Job) {
+	err := RunEach([]func() error{first, j.Run}) // want "missing errors.Is check for variadicflow.ErrFirst" "missing errors.Is check for variadicflow.ErrSecond"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Test 5: Transitive wrapper of a variadic higher-order function
+// =============================================================================
+
+func TestRunAllWrapper() {
+	err := RunAllWrapper(first) // want "missing errors.Is check for variadicflow.ErrFirst"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func wrapperWithFuncs() error { // want wrapperWithFuncs:`\[variadicflow.ErrSecond, variadicflow.ErrFirst\]`
+	return RunAll(first, func() error { return ErrSecond })
+}
+
+// =============================================================================
+// Test 7: Slice variable spread into a variadic parameter
+// =============================================================================
+
+func TestRunAllSliceVar() {
+	fns := []func() error{first, func() error { return ErrSecond }} // want fns:`\[variadicflow.ErrFirst, variadicflow.ErrSecond\]`
+	err := RunAll(fns...)                                           // want "missing errors.Is check for variadicflow.ErrFirst" "missing errors.Is check for variadicflow.ErrSecond"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+func TestRunEachSliceVar(j *Job) {
+	fns := []func() error{j.Run} // want fns:`\[variadicflow.ErrSecond\]`
+	err := RunEach(fns)          // want "missing errors.Is check for variadicflow.ErrSecond"
+	if err != nil {
+		println(err.Error())
+	}
+}
+
+// =============================================================================
+// Test 8: Local loop over a slice of functions
+// =============================================================================
+
+// runLocal calls each function of a slice built in its own body
+func runLocal() error { // want runLocal:`\[variadicflow.ErrSecond, variadicflow.ErrFirst\]`
+	for _, fn := range []func() error{first, func() error { return ErrSecond }} {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
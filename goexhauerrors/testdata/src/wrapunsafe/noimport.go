@@ -0,0 +1,9 @@
+package wrapunsafe
+
+// The fix adds the errors import to files that do not have it.
+func TestEqualWrappedNoImport() {
+	err := FindWrapped()
+	if err == ErrNotFound { // want "wrapunsafe.ErrNotFound may be returned wrapped, which == does not match; use errors.Is"
+		println("not found")
+	}
+}
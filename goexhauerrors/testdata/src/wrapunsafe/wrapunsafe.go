@@ -0,0 +1,143 @@
+package wrapunsafe
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:`wrapunsafe.ErrNotFound`
+
+var ErrUserNotFound = fmt.Errorf("user: %w", ErrNotFound) // want ErrUserNotFound:`wrapunsafe.ErrUserNotFound` ErrUserNotFound:`wraps:\[wrapunsafe.ErrNotFound\]`
+
+type ValidationError struct{} // want ValidationError:`wrapunsafe.ValidationError`
+
+func (e *ValidationError) Error() string { return "invalid" }
+
+func FindWrapped() error { // want FindWrapped:`\[wrapunsafe.ErrNotFound\]`
+	return fmt.Errorf("find: %w", ErrNotFound)
+}
+
+func FindDirect() error { // want FindDirect:`\[wrapunsafe.ErrNotFound\]`
+	return ErrNotFound
+}
+
+func FindUser() error { // want FindUser:`\[wrapunsafe.ErrUserNotFound\]`
+	return ErrUserNotFound
+}
+
+func ValidateWrapped() error { // want ValidateWrapped:`\[wrapunsafe.ValidationError\]`
+	return fmt.Errorf("validate: %w", &ValidationError{})
+}
+
+func ValidateDirect() error { // want ValidateDirect:`\[wrapunsafe.ValidationError\]`
+	return &ValidationError{}
+}
+
+// =============================================================================
+// Test 1: == against an error returned wrapped
+// =============================================================================
+
+func TestEqualWrapped() {
+	err := FindWrapped()
+	if err == ErrNotFound { // want "wrapunsafe.ErrNotFound may be returned wrapped, which == does not match; use errors.Is"
+		println("not found")
+	}
+}
+
+func TestNotEqualWrapped() {
+	err := FindWrapped()
+	if err != ErrNotFound { // want "wrapunsafe.ErrNotFound may be returned wrapped, which != does not match; use errors.Is"
+		println("other")
+	}
+}
+
+func TestEqualDirect() {
+	err := FindDirect()
+	if err == ErrNotFound {
+		println("not found")
+	}
+}
+
+func TestIsWrapped() {
+	err := FindWrapped()
+	if errors.Is(err, ErrNotFound) {
+		println("not found")
+	}
+}
+
+// =============================================================================
+// Test 2: == against the parent of a returned hierarchical sentinel
+// =============================================================================
+
+func TestEqualParent() {
+	err := FindUser()
+	if err == ErrNotFound { // want "wrapunsafe.ErrNotFound may be returned wrapped, which == does not match; use errors.Is"
+		println("not found")
+	}
+}
+
+func TestEqualChild() {
+	err := FindUser()
+	if err == ErrUserNotFound {
+		println("user not found")
+	}
+}
+
+// =============================================================================
+// Test 3: Type switch on an error type returned wrapped
+// =============================================================================
+
+func TestTypeSwitchWrapped() {
+	err := ValidateWrapped()
+	switch err.(type) {
+	case nil:
+		println("ok")
+	case *ValidationError: // want "wrapunsafe.ValidationError may be returned wrapped, which a type switch does not match; use errors.As"
+		println("invalid")
+	}
+}
+
+func TestTypeSwitchBoundWrapped() {
+	err := ValidateWrapped()
+	switch e := err.(type) {
+	case *ValidationError: // want "wrapunsafe.ValidationError may be returned wrapped, which a type switch does not match; use errors.As"
+		println(e.Error())
+	}
+}
+
+func TestTypeSwitchDirect() {
+	err := ValidateDirect()
+	switch err.(type) {
+	case *ValidationError:
+		println("invalid")
+	}
+}
+
+// =============================================================================
+// Test 4: Value switch on an error returned wrapped
+// =============================================================================
+
+func TestSwitchWrapped() {
+	err := FindWrapped()
+	switch err {
+	case nil:
+		println("ok")
+	case ErrNotFound: // want "wrapunsafe.ErrNotFound may be returned wrapped, which a switch does not match; use errors.Is"
+		println("not found")
+	}
+}
+
+func TestSwitchParent() {
+	switch err := FindUser(); err {
+	case ErrNotFound: // want "wrapunsafe.ErrNotFound may be returned wrapped, which a switch does not match; use errors.Is"
+		println("not found")
+	}
+}
+
+func TestSwitchDirect() {
+	err := FindDirect()
+	switch err {
+	case ErrNotFound:
+		println("not found")
+	}
+}
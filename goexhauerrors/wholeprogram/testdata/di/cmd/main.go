@@ -0,0 +1,14 @@
+package main
+
+import (
+	"example.com/di/infra"
+	"example.com/di/usecase"
+)
+
+func main() {
+	repo := &infra.Repository{}
+	usecase.Get(repo, "1")
+	usecase.Run(infra.Ping)
+	err := usecase.Load(repo, "1")
+	println(err != nil)
+}
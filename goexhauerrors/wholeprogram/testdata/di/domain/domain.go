@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
+
+type Repository interface {
+	Find(id string) error
+}
+
+// Handler is called through a function value.
+type Handler func() error
@@ -0,0 +1,3 @@
+module example.com/di
+
+go 1.23
@@ -0,0 +1,32 @@
+package infra
+
+import (
+	"errors"
+
+	"example.com/di/domain"
+)
+
+var ErrConnection = errors.New("connection")
+
+var ErrTimeout = errors.New("timeout")
+
+type Repository struct{}
+
+func (r *Repository) Find(id string) error {
+	if id == "" {
+		return domain.ErrNotFound
+	}
+	return ErrConnection
+}
+
+// UnusedRepository implements domain.Repository but is never passed to usecase.
+// VTA leaves it out of the call graph, CHA does not.
+type UnusedRepository struct{}
+
+func (r *UnusedRepository) Find(id string) error {
+	return ErrTimeout
+}
+
+func Ping() error {
+	return ErrTimeout
+}
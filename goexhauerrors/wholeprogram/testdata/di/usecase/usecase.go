@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"errors"
+
+	"example.com/di/domain"
+)
+
+// Get does not import infra, so its errors are only known in whole-program mode.
+func Get(repo domain.Repository, id string) bool {
+	err := repo.Find(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false
+	}
+	return err == nil
+}
+
+// Load propagates the errors of the repository to its callers.
+func Load(repo domain.Repository, id string) error {
+	return repo.Find(id)
+}
+
+// Run calls a function value.
+func Run(h domain.Handler) bool {
+	err := h()
+	return err != nil
+}
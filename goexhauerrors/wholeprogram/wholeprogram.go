@@ -0,0 +1,212 @@
+// Package wholeprogram implements the whole-program mode of goexhauerrors.
+//
+// The regular analysis sees one package and its dependencies at a time, so an
+// interface method call or a call through a function value can only be resolved to
+// implementations that are visible from the calling package. In whole-program mode
+// all packages are loaded together, a call graph is built for the program with VTA
+// (or CHA), and every dynamic call site is mapped to the errors of its possible
+// callees. The analysis is then repeated with that call-site index until the error
+// sets reach a fixed point.
+package wholeprogram
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/callsite"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/facts"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/internal"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// maxIterations bounds the fixed-point iteration over the call-site index.
+const maxIterations = 10
+
+// ErrNotConverged is returned by Run, together with the result of the last round, when
+// the call-site index still changed after maxIterations rounds.
+var ErrNotConverged = errors.New("call-site index did not reach a fixed point")
+
+// Run analyzes the packages matching patterns as a whole program and returns the
+// result of the final round. newAnalyzer returns the analyzer of a round, resolving
+// dynamic calls with the call-site index of the previous one. algo selects the call
+// graph construction: "vta" or "cha". If the index has not converged after
+// maxIterations rounds, the last result is returned with an error wrapping ErrNotConverged.
+func Run(newAnalyzer func(callsite.Index) *analysis.Analyzer, algo string, patterns []string) (*checker.Graph, error) {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errors.New("packages contain errors")
+	}
+
+	prog, _ := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	var cg *callgraph.Graph
+	switch algo {
+	case "vta":
+		cg = vta.CallGraph(ssautil.AllFunctions(prog), cha.CallGraph(prog))
+	case "cha":
+		cg = cha.CallGraph(prog)
+	default:
+		return nil, fmt.Errorf("unknown call graph algorithm %q (want vta or cha)", algo)
+	}
+	sites := dynamicCallSites(prog.Fset, cg)
+
+	idx := callsite.Index{}
+	for i := 0; ; i++ {
+		analyzer := newAnalyzer(idx)
+		graph, err := checker.Analyze([]*analysis.Analyzer{analyzer}, pkgs, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		next := buildIndex(prog.Fset, graph, analyzer, sites)
+		if reflect.DeepEqual(next, idx) {
+			return graph, nil
+		}
+		if i == maxIterations-1 {
+			return graph, fmt.Errorf("%w after %d rounds; diagnostics may be incomplete", ErrNotConverged, maxIterations)
+		}
+		idx = next
+	}
+}
+
+// dynamicCallSites returns the possible callees of every call site whose callee is
+// not statically known (interface method invocations and calls of function values).
+func dynamicCallSites(fset *token.FileSet, cg *callgraph.Graph) map[string][]*types.Func {
+	sites := make(map[string][]*types.Func)
+	for _, node := range cg.Nodes {
+		for _, edge := range node.Out {
+			if edge.Site == nil || edge.Site.Common().StaticCallee() != nil {
+				continue
+			}
+			fn := calleeObject(edge.Callee.Func)
+			if fn == nil {
+				continue
+			}
+			key := callsite.Key(fset.Position(edge.Site.Pos()))
+			sites[key] = append(sites[key], fn)
+		}
+	}
+	return sites
+}
+
+// calleeObject returns the declared function or method behind an SSA function,
+// looking through generic instantiations and synthetic wrappers.
+func calleeObject(fn *ssa.Function) *types.Func {
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	obj, _ := fn.Object().(*types.Func)
+	return obj
+}
+
+// buildIndex maps each dynamic call site to the union of the FunctionErrorsFacts of its callees.
+// Methods of mocks are skipped, like in the regular analysis.
+func buildIndex(fset *token.FileSet, graph *checker.Graph, analyzer *analysis.Analyzer, sites map[string][]*types.Func) callsite.Index {
+	funcErrors := make(map[*types.Func][]facts.ErrorInfo)
+	mocks := make(map[*types.TypeName]bool)
+	for act := range graph.All() {
+		if act.Analyzer != analyzer {
+			continue
+		}
+		for _, objFact := range act.AllObjectFacts() {
+			switch fact := objFact.Fact.(type) {
+			case *facts.FunctionErrorsFact:
+				if fn, ok := objFact.Object.(*types.Func); ok {
+					funcErrors[fn] = fact.Errors
+				}
+			case *facts.MockFact:
+				if tn, ok := objFact.Object.(*types.TypeName); ok {
+					mocks[tn] = true
+				}
+			}
+		}
+	}
+
+	idx := callsite.Index{}
+	for key, callees := range sites {
+		union := &facts.FunctionErrorsFact{}
+		for _, fn := range callees {
+			if tn := receiverTypeName(fn); tn != nil && (mocks[tn] || internal.MatchesMockPattern(fset, tn)) {
+				continue
+			}
+			for _, errInfo := range funcErrors[fn] {
+				union.AddError(errInfo)
+			}
+		}
+		if len(union.Errors) == 0 {
+			continue
+		}
+		sort.Slice(union.Errors, func(i, j int) bool {
+			return union.Errors[i].Key() < union.Errors[j].Key()
+		})
+		idx[key] = union.Errors
+	}
+	return idx
+}
+
+// receiverTypeName returns the type name of a method's receiver, or nil for functions.
+func receiverTypeName(fn *types.Func) *types.TypeName {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj()
+	}
+	return nil
+}
+
+// Main is the entry point of the whole-program command. It accepts the flags of the
+// analyzer plus -callgraph, runs the analysis and prints the diagnostics.
+// It exits with status 3 if diagnostics were reported, like singlechecker.
+func Main(newAnalyzer func(callsite.Index) *analysis.Analyzer, args []string) {
+	fs := flag.NewFlagSet("goexhauerrors whole", flag.ExitOnError)
+	algo := fs.String("callgraph", "vta", "call graph algorithm: vta or cha")
+	newAnalyzer(nil).Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: goexhauerrors whole [flags] packages...\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	graph, err := Run(newAnalyzer, *algo, fs.Args())
+	if errors.Is(err, ErrNotConverged) {
+		fmt.Fprintln(os.Stderr, "warning:", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := graph.PrintText(os.Stderr, -1); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for act := range graph.All() {
+		if act.IsRoot && len(act.Diagnostics) > 0 {
+			os.Exit(3)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package wholeprogram_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/wholeprogram"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		algo string
+		want []string
+	}{
+		{"vta", []string{
+			"cmd/main.go:12: missing errors.Is check for example.com/di/domain.ErrNotFound",
+			"cmd/main.go:12: missing errors.Is check for example.com/di/infra.ErrConnection",
+			"usecase/usecase.go:11: missing errors.Is check for example.com/di/infra.ErrConnection",
+			"usecase/usecase.go:25: missing errors.Is check for example.com/di/infra.ErrTimeout",
+		}},
+		{"cha", []string{
+			"cmd/main.go:12: missing errors.Is check for example.com/di/domain.ErrNotFound",
+			"cmd/main.go:12: missing errors.Is check for example.com/di/infra.ErrConnection",
+			"cmd/main.go:12: missing errors.Is check for example.com/di/infra.ErrTimeout",
+			"usecase/usecase.go:11: missing errors.Is check for example.com/di/infra.ErrConnection",
+			"usecase/usecase.go:11: missing errors.Is check for example.com/di/infra.ErrTimeout",
+			"usecase/usecase.go:25: missing errors.Is check for example.com/di/infra.ErrTimeout",
+		}},
+	}
+
+	dir, err := filepath.Abs(filepath.Join("testdata", "di"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	for _, tt := range tests {
+		t.Run(tt.algo, func(t *testing.T) {
+			graph, err := wholeprogram.Run(goexhauerrors.WholeProgramAnalyzer, tt.algo, []string{"./..."})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			var got []string
+			for act := range graph.All() {
+				if !act.IsRoot {
+					continue
+				}
+				for _, d := range act.Diagnostics {
+					posn := act.Package.Fset.Position(d.Pos)
+					rel, _ := filepath.Rel(dir, posn.Filename)
+					got = append(got, fmt.Sprintf("%s:%d: %s", filepath.ToSlash(rel), posn.Line, d.Message))
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diagnostics =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
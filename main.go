@@ -1,10 +1,23 @@
 package main
 
 import (
+	"os"
+
 	"github.com/YuitoSato/goexhauerrors/goexhauerrors"
+	"github.com/YuitoSato/goexhauerrors/goexhauerrors/wholeprogram"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
 func main() {
+	// goexhauerrors diff [-snapshot=dir] [-baseline=dir] packages...
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		singlechecker.Main(goexhauerrors.DiffAnalyzer)
+	}
+	// goexhauerrors whole [-callgraph=vta|cha] packages...
+	if len(os.Args) > 1 && os.Args[1] == "whole" {
+		wholeprogram.Main(goexhauerrors.WholeProgramAnalyzer, os.Args[2:])
+		os.Exit(0)
+	}
 	singlechecker.Main(goexhauerrors.Analyzer)
 }